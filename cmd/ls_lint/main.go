@@ -1,22 +1,24 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
-	"maps"
 	"os"
+	"path/filepath"
 	"runtime"
 	"slices"
-	"strings"
 
+	"github.com/loeffel-io/ls-lint/v2/internal/baseline"
 	"github.com/loeffel-io/ls-lint/v2/internal/config"
 	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/fix"
 	_flag "github.com/loeffel-io/ls-lint/v2/internal/flag"
+	"github.com/loeffel-io/ls-lint/v2/internal/git"
 	"github.com/loeffel-io/ls-lint/v2/internal/linter"
+	"github.com/loeffel-io/ls-lint/v2/internal/lsp"
+	"github.com/loeffel-io/ls-lint/v2/internal/output"
 	"github.com/loeffel-io/ls-lint/v2/internal/rule"
-	"go.yaml.in/yaml/v3"
 )
 
 var Version = "dev"
@@ -27,16 +29,26 @@ func main() {
 	writer := os.Stdout
 	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 	flagWorkdir := flags.String("workdir", ".", "change working directory before executing the given subcommand")
-	flagErrorOutputFormat := flags.String("error-output-format", "text", "use a specific error output format (text, json)")
+	flagErrorOutputFormat := flags.String("error-output-format", "text", "use a specific error output format (text, json, json-v2, sarif, junit, github, gitlab, checkstyle)")
+	flagJUnitPassing := flags.Bool("junit-passing", false, "add linted paths without errors as passing testcases (junit only)")
 	flagWarn := flags.Bool("warn", false, "write lint errors to stdout instead of stderr (exit 0)")
+	flagMaxWarnings := flags.Int("max-warnings", -1, "exit 1 if the number of warnings exceeds the given budget (default unlimited)")
 	flagDebug := flags.Bool("debug", false, "write debug informations to stdout")
 	flagVersion := flags.Bool("version", false, "prints version information for ls-lint")
+	flagGitignore := flags.Bool("gitignore", false, "skip all files and directories ignored by .gitignore files")
+	flagNestedConfig := flags.Bool("nested-config", false, "scope .ls-lint.yml files of subdirectories to their subtree")
+	flagStaged := flags.Bool("staged", false, "lint only files and directories added to the git index")
+	flagDiffBase := flags.String("diff-base", "", "lint only files and directories added to the git index compared to the given git ref")
+	flagDryRun := flags.Bool("dry-run", false, "print the rename plan without renaming (fix only)")
+	flagBaseline := flags.String("baseline", "", "suppress the errors recorded in the given baseline file and report entries which no longer occur")
+	flagWriteBaseline := flags.String("write-baseline", "", "record all lint errors in the given baseline file (exit 0)")
+	flagWatch := flags.Bool("watch", false, "lint again on filesystem changes and print newly introduced (+) and resolved (-) errors (linux only)")
 
 	var flagConfig _flag.Config
 	flags.Var(&flagConfig, "config", "ls-lint config file path(s)")
 
 	flags.Usage = func() {
-		if _, err = fmt.Fprintln(flags.Output(), "ls-lint [options] [file|dir]*"); err != nil {
+		if _, err = fmt.Fprintln(flags.Output(), "ls-lint [fix|lsp] [options] [file|dir]*"); err != nil {
 			log.Fatal(err)
 		}
 
@@ -47,7 +59,13 @@ func main() {
 		flags.PrintDefaults()
 	}
 
-	if err = flags.Parse(os.Args[1:]); err != nil {
+	args := os.Args[1:]
+	var command string
+	if len(args) > 0 && (args[0] == "fix" || args[0] == "lsp") {
+		command, args = args[0], args[1:]
+	}
+
+	if err = flags.Parse(args); err != nil {
 		log.Fatal(err)
 	}
 
@@ -69,23 +87,101 @@ func main() {
 		}
 	}
 
-	lslintConfig := config.NewConfig(make(config.Ls), make([]string, 0))
-	for _, c := range flagConfig {
-		tmpLslintConfig := config.NewConfig(nil, nil)
-		var tmpConfigBytes []byte
+	if *flagWriteBaseline != "" && (command != "" || *flagBaseline != "" || *flagWatch || paths != nil || *flagStaged || *flagDiffBase != "") {
+		log.Fatal("--write-baseline can not be combined with fix, lsp, paths, --baseline, --watch, --staged or --diff-base")
+	}
+
+	if *flagStaged || *flagDiffBase != "" {
+		if *flagStaged && *flagDiffBase != "" {
+			log.Fatal("--staged and --diff-base can not be combined")
+		}
+
+		if paths != nil {
+			log.Fatal("--staged and --diff-base can not be combined with paths")
+		}
+
+		var repo *git.Repository
+		if repo, err = git.Open(*flagWorkdir); err != nil {
+			log.Fatal(err)
+		}
 
-		if tmpConfigBytes, err = os.ReadFile(c); err != nil {
+		var changes []string
+		switch *flagStaged {
+		case true:
+			changes, err = repo.Staged()
+		case false:
+			changes, err = repo.Diff(*flagDiffBase)
+		}
+
+		if err != nil {
 			log.Fatal(err)
 		}
 
-		if err = yaml.Unmarshal(tmpConfigBytes, tmpLslintConfig); err != nil {
+		if err = repo.Close(); err != nil {
 			log.Fatal(err)
 		}
 
-		maps.Copy(lslintConfig.GetLs(), tmpLslintConfig.GetLs())
-		lslintConfig.Ignore = append(lslintConfig.Ignore, tmpLslintConfig.GetIgnore()...)
-		slices.Sort(lslintConfig.Ignore)
-		lslintConfig.Ignore = slices.Compact(lslintConfig.Ignore)
+		// nothing to lint
+		if len(changes) == 0 {
+			os.Exit(exitCode)
+		}
+
+		paths = make(map[string]struct{}, len(changes))
+		for _, path := range changes {
+			paths[path] = struct{}{}
+		}
+	}
+
+	// relative config paths are resolved against the given dir
+	loadConfig := func(dir string) (*config.Config, error) {
+		lslintConfig := config.NewConfig(make(config.Ls), make([]string, 0))
+		for _, c := range flagConfig {
+			if dir != "" && !filepath.IsAbs(c) {
+				c = filepath.Join(dir, c)
+			}
+
+			tmpLslintConfig, err := config.Load(os.ReadFile, c)
+			if err != nil {
+				return nil, err
+			}
+
+			lslintConfig.Merge(tmpLslintConfig)
+		}
+
+		if *flagGitignore {
+			lslintConfig.UseGitignore = true
+		}
+
+		if *flagNestedConfig {
+			lslintConfig.NestedConfig = true
+		}
+
+		return lslintConfig, nil
+	}
+
+	if command == "lsp" {
+		if err = lsp.NewServer(os.Stdin, os.Stdout, *flagWorkdir, loadConfig, Version).Serve(); err != nil {
+			log.Fatal(err)
+		}
+
+		os.Exit(exitCode)
+	}
+
+	var lslintConfig *config.Config
+	if lslintConfig, err = loadConfig(""); err != nil {
+		log.Fatal(err)
+	}
+
+	if *flagWatch {
+		if command != "" || paths != nil {
+			log.Fatal("--watch can not be combined with fix, paths, --staged or --diff-base")
+		}
+
+		if err = watchLint(writer, *flagWorkdir, lslintConfig, loadConfig); err != nil {
+			log.Fatal(err)
+		}
+
+		os.Exit(exitCode)
 	}
 
 	lslintLinter := linter.NewLinter(
@@ -101,67 +197,143 @@ func main() {
 
 	ruleErrors := lslintLinter.GetErrors()
 
-	if len(ruleErrors) == 0 {
-		os.Exit(exitCode)
-	}
+	if command == "fix" {
+		var renames []*fix.Rename
+		var skips []*fix.Skip
 
-	if !*flagWarn {
-		writer = os.Stderr
-		exitCode = 1
-	}
+		if renames, skips, err = fix.Plan(filesystem, ruleErrors); err != nil {
+			log.Fatal(err)
+		}
 
-	switch *flagErrorOutputFormat {
-	case "json":
-		errIndex := make(map[string]map[string][]string, len(lslintLinter.GetErrors()))
-		for _, ruleErr := range lslintLinter.GetErrors() {
-			path := ruleErr.GetPath()
-			if path == "" {
-				path = "."
+		for _, rename := range renames {
+			if _, err = fmt.Fprintf(writer, "%s -> %s (%s)\n", rename.From, rename.To, rename.Rule); err != nil {
+				log.Fatal(err)
 			}
+		}
 
-			if _, ok := errIndex[path]; !ok {
-				errIndex[path] = make(map[string][]string)
+		if !*flagDryRun {
+			if err = fix.Apply(*flagWorkdir, renames); err != nil {
+				log.Fatal(err)
 			}
+		}
 
-			for _, errRule := range ruleErr.GetRules() {
-				if !ruleErr.IsDir() && errRule.GetName() == "exists" {
-					continue
-				}
+		if len(skips) == 0 {
+			os.Exit(exitCode)
+		}
 
-				errIndex[path][ruleErr.GetExt()] = append(errIndex[path][ruleErr.GetExt()], errRule.GetErrorMessage())
+		if !*flagWarn {
+			writer = os.Stderr
+			exitCode = 1
+		}
+
+		for _, skip := range skips {
+			if _, err = fmt.Fprintf(writer, "%s skipped: %s\n", skip.Path, skip.Reason); err != nil {
+				log.Fatal(err)
 			}
 		}
 
-		var jsonStr []byte
-		if jsonStr, err = json.Marshal(errIndex); err != nil {
+		os.Exit(exitCode)
+	}
+
+	if *flagWriteBaseline != "" {
+		if err = writeBaseline(*flagWriteBaseline, ruleErrors); err != nil {
 			log.Fatal(err)
 		}
 
-		if _, err = fmt.Fprintln(writer, string(jsonStr)); err != nil {
+		os.Exit(exitCode)
+	}
+
+	// partial runs don't lint all exempted paths
+	if paths == nil {
+		for _, pattern := range lslintLinter.GetUnusedExceptions() {
+			if _, err = fmt.Fprintf(os.Stderr, "unused exception: %s\n", pattern); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	if *flagBaseline != "" {
+		var lintBaseline *baseline.Baseline
+		if lintBaseline, err = readBaseline(*flagBaseline); err != nil {
 			log.Fatal(err)
 		}
-	default:
-		for _, ruleErr := range lslintLinter.GetErrors() {
-			var ruleMessages []string
 
-			path := ruleErr.GetPath()
-			if path == "" {
-				path = "."
-			}
+		var stale []*baseline.Entry
+		ruleErrors, stale = lintBaseline.Filter(ruleErrors)
 
-			for _, errRule := range ruleErr.GetRules() {
-				if !ruleErr.IsDir() && errRule.GetName() == "exists" {
-					continue
+		// partial runs don't lint the paths of all entries
+		if paths == nil {
+			for _, entry := range stale {
+				if _, err = fmt.Fprintf(os.Stderr, "stale baseline entry: %s\n", entry); err != nil {
+					log.Fatal(err)
 				}
-
-				ruleMessages = append(ruleMessages, errRule.GetErrorMessage())
 			}
+		}
+	}
 
-			if _, err = fmt.Fprintf(writer, "%s failed for `%s` rules: %s\n", path, ruleErr.GetExt(), strings.Join(ruleMessages, " | ")); err != nil {
-				log.Fatal(err)
-			}
+	// sarif logs and structured reports are written without errors, too
+	if len(ruleErrors) == 0 && !slices.Contains([]string{"json-v2", "sarif", "junit", "gitlab", "checkstyle"}, *flagErrorOutputFormat) {
+		os.Exit(exitCode)
+	}
+
+	// warnings and infos are written to stdout unless they exceed the warning budget
+	if output.ExitCode(ruleErrors, *flagMaxWarnings) > 0 && !*flagWarn {
+		writer = os.Stderr
+		exitCode = 1
+	}
+
+	switch *flagErrorOutputFormat {
+	case "json":
+		err = output.JSON(writer, ruleErrors)
+	case "json-v2":
+		err = output.JSONv2(writer, ruleErrors, lslintLinter.GetStatistics())
+	case "sarif":
+		err = output.SARIF(writer, ruleErrors, Version, *flagWorkdir)
+	case "junit":
+		var linted map[string][]string
+		if *flagJUnitPassing {
+			linted = lslintLinter.GetLintedPaths()
 		}
+
+		err = output.JUnit(writer, ruleErrors, linted)
+	case "github":
+		err = output.GitHub(writer, ruleErrors, *flagWorkdir)
+	case "gitlab":
+		err = output.GitLab(writer, ruleErrors, *flagWorkdir)
+	case "checkstyle":
+		err = output.Checkstyle(writer, ruleErrors)
+	default:
+		err = output.Text(writer, ruleErrors)
+	}
+
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	os.Exit(exitCode)
 }
+
+func readBaseline(name string) (*baseline.Baseline, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return baseline.Read(file)
+}
+
+func writeBaseline(name string, ruleErrors []*rule.Error) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err = baseline.New(ruleErrors).Write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
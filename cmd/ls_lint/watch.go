@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/config"
+	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/linter"
+	"github.com/loeffel-io/ls-lint/v2/internal/output"
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+	"github.com/loeffel-io/ls-lint/v2/internal/watch"
+)
+
+// watchLint lints the workdir and re-lints the changed paths until the process is stopped
+// changes of the config files and their extended config files reload the config
+func watchLint(writer io.Writer, workdir string, lslintConfig *config.Config, loadConfig func(dir string) (*config.Config, error)) (err error) {
+	incremental := linter.NewIncremental(
+		linter.NewLinter(".", lslintConfig, debug.NewStatistic(), make([]*rule.Error, 0)),
+		os.DirFS(workdir),
+	)
+
+	if err = incremental.Init(); err != nil {
+		return err
+	}
+
+	if err = output.Text(writer, incremental.GetErrors()); err != nil {
+		return err
+	}
+
+	var configPaths map[string]struct{}
+	if configPaths, err = getConfigPaths(lslintConfig); err != nil {
+		return err
+	}
+
+	var watcher *watch.Watcher
+	if watcher, err = watch.New(workdir, incremental.ShouldIgnore); err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	if _, err = fmt.Fprintf(writer, "watching %s\n", workdir); err != nil {
+		return err
+	}
+
+	for {
+		var changes []string
+		if changes, err = watcher.Next(); err != nil {
+			return err
+		}
+
+		reload := false
+		for _, change := range changes {
+			var changePath string
+			if changePath, err = filepath.Abs(filepath.Join(workdir, change)); err != nil {
+				return err
+			}
+
+			if _, ok := configPaths[changePath]; ok {
+				reload = true
+			}
+		}
+
+		var introduced, resolved []*rule.Error
+		switch reload {
+		case true:
+			var tmpLslintConfig *config.Config
+			if tmpLslintConfig, err = loadConfig(""); err == nil {
+				introduced, resolved, err = incremental.Reload(tmpLslintConfig)
+			}
+
+			// the extends of the reloaded config may have changed
+			if err == nil {
+				configPaths, err = getConfigPaths(tmpLslintConfig)
+			}
+		case false:
+			introduced, resolved, err = incremental.Update(changes)
+		}
+
+		// invalid configs are reported until they are fixed
+		if err != nil {
+			if _, err = fmt.Fprintln(os.Stderr, err.Error()); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err = output.Diff(writer, introduced, resolved); err != nil {
+			return err
+		}
+	}
+}
+
+// getConfigPaths returns the absolute paths of the loaded config files including the extended config files
+func getConfigPaths(lslintConfig *config.Config) (map[string]struct{}, error) {
+	configPaths := make(map[string]struct{}, len(lslintConfig.GetFiles()))
+	for _, c := range lslintConfig.GetFiles() {
+		configPath, err := filepath.Abs(c)
+		if err != nil {
+			return nil, err
+		}
+
+		configPaths[configPath] = struct{}{}
+	}
+
+	return configPaths, nil
+}
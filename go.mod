@@ -6,4 +6,5 @@ require (
 	github.com/bmatcuk/doublestar/v4 v4.8.1
 	go.yaml.in/yaml/v3 v3.0.4
 	golang.org/x/sync v0.14.0
+	golang.org/x/text v0.25.0
 )
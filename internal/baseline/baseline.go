@@ -0,0 +1,147 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const version = 1
+
+// Baseline contains the errors of a run which are suppressed on later runs
+type Baseline struct {
+	Version int      `json:"version"`
+	Entries []*Entry `json:"entries"`
+}
+
+// Entry is a suppressed error identified by its path, extension and failed rules
+// rules contain their parameters (e.g. the colliding names of unique-casefold) and the operands of expressions
+type Entry struct {
+	Path  string   `json:"path"`
+	Ext   string   `json:"ext"`
+	Rules []string `json:"rules"`
+}
+
+func (entry *Entry) String() string {
+	return fmt.Sprintf("%s failed for `%s` rules: %s", entry.Path, entry.Ext, strings.Join(entry.Rules, " | "))
+}
+
+func (entry *Entry) key() string {
+	return strings.Join(append([]string{entry.Path, entry.Ext}, entry.Rules...), "\x00")
+}
+
+func newEntry(ruleErr *rule.Error) *Entry {
+	entry := &Entry{
+		Path:  ruleErr.GetPath(),
+		Ext:   ruleErr.GetExt(),
+		Rules: make([]string, 0, len(ruleErr.GetRules())),
+	}
+
+	if entry.Path == "" {
+		entry.Path = "."
+	}
+
+	for _, errRule := range ruleErr.GetFailedRules() {
+		entry.Rules = append(entry.Rules, entryRule(errRule))
+	}
+
+	slices.Sort(entry.Rules)
+	return entry
+}
+
+// entryRule returns the name of the rule with its parameters (e.g. regex:^index$) or operands (e.g. or(kebabcase, regex:^index$))
+func entryRule(r rule.Rule) string {
+	if expression, ok := rule.Unwrap(r).(*rule.Expression); ok {
+		operands := make([]string, 0, len(expression.GetOperands()))
+		for _, operand := range expression.GetOperands() {
+			operands = append(operands, entryRule(operand))
+		}
+
+		return fmt.Sprintf("%s(%s)", expression.GetName(), strings.Join(operands, ", "))
+	}
+
+	if params := r.GetParameters(); len(params) > 0 {
+		return fmt.Sprintf("%s:%s", r.GetName(), strings.Join(params, ","))
+	}
+
+	return r.GetName()
+}
+
+// New returns the baseline of the errors
+// entries are sorted by path and extension to keep the diffs of baseline files small
+func New(ruleErrors []*rule.Error) *Baseline {
+	entries := make([]*Entry, 0, len(ruleErrors))
+	for _, ruleErr := range ruleErrors {
+		entries = append(entries, newEntry(ruleErr))
+	}
+
+	slices.SortFunc(entries, func(a *Entry, b *Entry) int {
+		return strings.Compare(a.key(), b.key())
+	})
+
+	return &Baseline{Version: version, Entries: slices.CompactFunc(entries, func(a *Entry, b *Entry) bool {
+		return a.key() == b.key()
+	})}
+}
+
+// Read reads a baseline file
+func Read(reader io.Reader) (*Baseline, error) {
+	var baseline Baseline
+	if err := json.NewDecoder(reader).Decode(&baseline); err != nil {
+		return nil, fmt.Errorf("invalid baseline: %s", err.Error())
+	}
+
+	if baseline.Version != version {
+		return nil, fmt.Errorf("baseline version %d not supported", baseline.Version)
+	}
+
+	for _, entry := range baseline.Entries {
+		slices.Sort(entry.Rules)
+	}
+
+	return &baseline, nil
+}
+
+// Write writes the baseline as indented json
+func (baseline *Baseline) Write(writer io.Writer) error {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(writer, string(data))
+	return err
+}
+
+// Filter returns the errors which are not part of the baseline and the stale entries which don't occur anymore
+func (baseline *Baseline) Filter(ruleErrors []*rule.Error) ([]*rule.Error, []*Entry) {
+	entries := make(map[string]*Entry, len(baseline.Entries))
+	for _, entry := range baseline.Entries {
+		entries[entry.key()] = entry
+	}
+
+	matched := make(map[string]bool, len(baseline.Entries))
+	introduced := make([]*rule.Error, 0)
+	for _, ruleErr := range ruleErrors {
+		key := newEntry(ruleErr).key()
+		if _, ok := entries[key]; ok {
+			matched[key] = true
+			continue
+		}
+
+		introduced = append(introduced, ruleErr)
+	}
+
+	stale := make([]*Entry, 0)
+	for _, entry := range baseline.Entries {
+		if !matched[entry.key()] {
+			stale = append(stale, entry)
+		}
+	}
+
+	return introduced, stale
+}
@@ -0,0 +1,137 @@
+package baseline
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+func getError(path string, ext string, rules ...rule.Rule) *rule.Error {
+	return &rule.Error{
+		Path:    path,
+		Ext:     ext,
+		Rules:   rules,
+		RWMutex: new(sync.RWMutex),
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	kebab := new(rule.KebabCase).Init()
+	snake := new(rule.SnakeCase).Init()
+	exists := new(rule.Exists).Init()
+
+	recorded := []*rule.Error{
+		getError("src/NotKebab.ts", ".ts", kebab, exists),
+		getError("src/Legacy.ts", ".ts", snake, kebab),
+		getError("", ".dir", kebab),
+		getError("src/Legacy.ts", ".ts", kebab, snake),
+	}
+
+	var buffer bytes.Buffer
+	if err := New(recorded).Write(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := Read(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*Entry{
+		{Path: ".", Ext: ".dir", Rules: []string{"kebabcase"}},
+		{Path: "src/Legacy.ts", Ext: ".ts", Rules: []string{"kebabcase", "snakecase"}},
+		{Path: "src/NotKebab.ts", Ext: ".ts", Rules: []string{"kebabcase"}},
+	}
+
+	if !reflect.DeepEqual(res.Entries, expected) {
+		t.Fatalf("unmatched baseline entries - %+v", res.Entries)
+	}
+
+	tests := []*struct {
+		ruleErrors []*rule.Error
+		introduced []string
+		stale      []string
+	}{
+		{
+			ruleErrors: recorded,
+			introduced: []string{},
+			stale:      []string{},
+		},
+		{
+			ruleErrors: []*rule.Error{
+				getError("src/Legacy.ts", ".ts", kebab, snake),
+				getError("src/NewFile.ts", ".ts", kebab),
+				getError("src/NotKebab.ts", ".ts", snake),
+			},
+			introduced: []string{"src/NewFile.ts", "src/NotKebab.ts"},
+			stale:      []string{".", "src/NotKebab.ts"},
+		},
+	}
+
+	i := 0
+	for _, test := range tests {
+		introduced, stale := res.Filter(test.ruleErrors)
+
+		introducedPaths := make([]string, 0, len(introduced))
+		for _, ruleErr := range introduced {
+			introducedPaths = append(introducedPaths, ruleErr.GetPath())
+		}
+
+		stalePaths := make([]string, 0, len(stale))
+		for _, entry := range stale {
+			stalePaths = append(stalePaths, entry.Path)
+		}
+
+		if !reflect.DeepEqual(introducedPaths, test.introduced) || !reflect.DeepEqual(stalePaths, test.stale) {
+			t.Errorf("Test %d failed with unmatched return value - %+v %+v", i, introducedPaths, stalePaths)
+		}
+
+		i++
+	}
+
+	if _, err = Read(bytes.NewBufferString(`{"version":2,"entries":[]}`)); err == nil {
+		t.Errorf("Test failed without unsupported version error")
+	}
+}
+
+func TestBaseline_RuleParameters(t *testing.T) {
+	kebab := new(rule.KebabCase).Init()
+	snake := new(rule.SnakeCase).Init()
+	uniqueCasefold := new(rule.UniqueCasefold).Init().(*rule.UniqueCasefold)
+
+	recorded := []*rule.Error{
+		getError("src", ".dir", uniqueCasefold.WithCollisions([]string{"A.ts", "a.ts"})),
+		getError("src/Legacy.ts", ".ts", rule.NewOr([]rule.Rule{kebab, snake})),
+	}
+
+	expected := []*Entry{
+		{Path: "src", Ext: ".dir", Rules: []string{"unique-casefold:A.ts,a.ts"}},
+		{Path: "src/Legacy.ts", Ext: ".ts", Rules: []string{"or(kebabcase, snakecase)"}},
+	}
+
+	res := New(recorded)
+	if !reflect.DeepEqual(res.Entries, expected) {
+		t.Fatalf("unmatched baseline entries - %+v", res.Entries)
+	}
+
+	// other collision partners and operands are introduced errors
+	tests := []*struct {
+		ruleErr    *rule.Error
+		introduced bool
+	}{
+		{ruleErr: recorded[0], introduced: false},
+		{ruleErr: recorded[1], introduced: false},
+		{ruleErr: getError("src", ".dir", uniqueCasefold.WithCollisions([]string{"B.ts", "b.ts"})), introduced: true},
+		{ruleErr: getError("src/Legacy.ts", ".ts", rule.NewOr([]rule.Rule{kebab})), introduced: true},
+		{ruleErr: getError("src/Legacy.ts", ".ts", rule.NewAnd([]rule.Rule{kebab, snake})), introduced: true},
+	}
+
+	for i, test := range tests {
+		if introduced, _ := res.Filter([]*rule.Error{test.ruleErr}); (len(introduced) == 1) != test.introduced {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, introduced)
+		}
+	}
+}
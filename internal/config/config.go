@@ -2,7 +2,6 @@ package config
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 	"sync"
 
@@ -20,8 +19,22 @@ const (
 )
 
 type Config struct {
-	Ls     Ls       `yaml:"ls"`
-	Ignore []string `yaml:"ignore"`
+	// Extends are config files or presets (preset:name) which are merged before this config
+	Extends Extends  `yaml:"extends"`
+	Ls      Ls       `yaml:"ls"`
+	Ignore  []string `yaml:"ignore"`
+	// UseGitignore skips all paths ignored by .gitignore files
+	UseGitignore bool `yaml:"use_gitignore"`
+	// NestedConfig scopes .ls-lint.yml files of subdirectories to their subtree
+	NestedConfig bool `yaml:"nested_config"`
+	// Exceptions exempt paths from rules by exact path or glob
+	Exceptions map[string]*Exception `yaml:"exceptions"`
+	// Rules are custom rules which are looked up before the builtin rules
+	Rules map[string]rule.Rule `yaml:"-"`
+	// Origins are the config files of the ls rules by ls key and extension
+	Origins map[string]map[string]string `yaml:"-"`
+	// Files are the loaded config files including the extended config files - presets are left out
+	Files []string `yaml:"-"`
 	*sync.RWMutex
 }
 
@@ -47,6 +60,50 @@ func (config *Config) GetIgnore() []string {
 	return config.Ignore
 }
 
+func (config *Config) GetUseGitignore() bool {
+	config.RLock()
+	defer config.RUnlock()
+
+	return config.UseGitignore
+}
+
+func (config *Config) GetNestedConfig() bool {
+	config.RLock()
+	defer config.RUnlock()
+
+	return config.NestedConfig
+}
+
+func (config *Config) GetOrigins() map[string]map[string]string {
+	config.RLock()
+	defer config.RUnlock()
+
+	return config.Origins
+}
+
+func (config *Config) GetFiles() []string {
+	config.RLock()
+	defer config.RUnlock()
+
+	return config.Files
+}
+
+func (config *Config) GetRules() map[string]rule.Rule {
+	config.RLock()
+	defer config.RUnlock()
+
+	return config.Rules
+}
+
+func (config *Config) getRule(name string) (rule.Rule, bool) {
+	if r, ok := config.GetRules()[name]; ok {
+		return r, true
+	}
+
+	r, ok := rule.Rules[name]
+	return r, ok
+}
+
 func (config *Config) GetIgnoreIndex() map[string]bool {
 	ignoreIndex := make(map[string]bool)
 
@@ -105,15 +162,24 @@ func (config *Config) walkIndex(index RuleIndex, key string, list Ls) error {
 			continue
 		}
 
-		if reflect.TypeOf(v).Kind() == reflect.Map {
+		set, err := toRuleSet(v)
+		if err != nil {
+			return fmt.Errorf("%s failed with %s", k, err.Error())
+		}
+
+		if set != nil {
+			v = set.rules
+		}
+
+		if childList, ok := toLs(v); ok {
 			switch key == "" {
 			case true:
-				if err := config.walkIndex(index, k, v.(Ls)); err != nil {
+				if err := config.walkIndex(index, k, childList); err != nil {
 					return err
 				}
 			case false:
 				keyCombination := fmt.Sprintf("%s%s%s", key, sep, k)
-				if err := config.walkIndex(index, keyCombination, v.(Ls)); err != nil {
+				if err := config.walkIndex(index, keyCombination, childList); err != nil {
 					return err
 				}
 			}
@@ -121,24 +187,26 @@ func (config *Config) walkIndex(index RuleIndex, key string, list Ls) error {
 			continue
 		}
 
-		for _, ruleName := range strings.Split(v.(string), or) {
-			ruleName = strings.TrimSpace(ruleName)
-			ruleSplit := strings.SplitN(ruleName, ":", 2)
-			ruleName = ruleSplit[0]
+		var parsedRules []rule.Rule
 
-			if r, ok := rule.Rules[ruleName]; ok {
-				r = r.Copy()
-
-				if err := r.SetParameters(ruleSplit[1:]); err != nil {
-					return fmt.Errorf("rule %s failed with %s", ruleName, err.Error())
-				}
+		switch rules := v.(type) {
+		case string:
+			parsedRules, err = config.parseRules(rules)
+		case []interface{}:
+			parsedRules, err = config.parseRuleList(rules)
+		default:
+			return fmt.Errorf("%s must be a rule string, a list of rules or a mapping, found %T", k, v)
+		}
 
-				index[key][k] = append(index[key][k], r)
-				continue
-			}
+		if err != nil {
+			return err
+		}
 
-			return fmt.Errorf("rule %s not exists", ruleName)
+		if set != nil {
+			parsedRules = set.document(parsedRules)
 		}
+
+		index[key][k] = append(index[key][k], parsedRules...)
 	}
 
 	return nil
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+)
+
+// Exception exempts the paths matching its key (exact path or glob) from the rules
+// exempted paths are still counted by exists rules
+//
+//	exceptions:
+//	  src/README.md:
+//	    rules: [kebab-case]
+//	    reason: readme files are named by convention
+type Exception struct {
+	Rules  []string `yaml:"rules"`
+	Reason string   `yaml:"reason"`
+}
+
+func (config *Config) GetExceptions() map[string]*Exception {
+	config.RLock()
+	defer config.RUnlock()
+
+	return config.Exceptions
+}
+
+// GetExceptionRules returns the names of the rules of the exception
+// rule aliases are resolved to the rule names (e.g. kebab-case => kebabcase)
+func (config *Config) GetExceptionRules(exception *Exception) (map[string]bool, error) {
+	if exception.Reason == "" {
+		return nil, fmt.Errorf("exception reason not exists")
+	}
+
+	if len(exception.Rules) == 0 {
+		return nil, fmt.Errorf("exception rules not exists")
+	}
+
+	names := make(map[string]bool, len(exception.Rules))
+	for _, name := range exception.Rules {
+		r, ok := config.getRule(name)
+		if !ok {
+			return nil, fmt.Errorf("rule %s not exists", name)
+		}
+
+		if r.GetExclusive() {
+			return nil, fmt.Errorf("rule %s can't be excepted", name)
+		}
+
+		names[r.GetName()] = true
+	}
+
+	return names, nil
+}
+
+// mergeExceptions merges the exceptions of the source - exceptions of the source take precedence
+func mergeExceptions(target map[string]*Exception, source map[string]*Exception) map[string]*Exception {
+	if len(source) == 0 {
+		return target
+	}
+
+	if target == nil {
+		target = make(map[string]*Exception, len(source))
+	}
+
+	maps.Copy(target, source)
+	return target
+}
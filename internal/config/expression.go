@@ -0,0 +1,257 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const and = " & "
+
+type tokenKind int
+
+const (
+	tokenRule tokenKind = iota
+	tokenOr
+	tokenAnd
+	tokenOpen
+	tokenClose
+)
+
+// token is a rule, an operator or a parenthesis of a rule string at its byte offset
+type token struct {
+	kind   tokenKind
+	value  string
+	offset int
+}
+
+var (
+	errMissingRule  = errors.New("missing rule")
+	errMissingClose = errors.New("missing )")
+	errUnexpected   = errors.New("unexpected )")
+)
+
+// tokenize splits the rule string into rules, operators and parentheses
+// parentheses group rules only at the start and the end of a rule
+// so regex patterns like regex:(a|b) keep their parentheses
+func tokenize(value string) ([]*token, error) {
+	tokens := make([]*token, 0)
+	depth := 0
+
+	for offset := 0; offset <= len(value); {
+		end, kind := len(value), tokenKind(-1)
+		if i := strings.Index(value[offset:], or); i >= 0 {
+			end, kind = offset+i, tokenOr
+		}
+
+		if i := strings.Index(value[offset:], and); i >= 0 && offset+i < end {
+			end, kind = offset+i, tokenAnd
+		}
+
+		operand := value[offset:end]
+		start := offset + len(operand) - len(strings.TrimLeft(operand, " "))
+		operand = strings.TrimSpace(operand)
+
+		for strings.HasPrefix(operand, "(") {
+			tokens = append(tokens, &token{kind: tokenOpen, value: "(", offset: start})
+			operand, start, depth = operand[1:], start+1, depth+1
+		}
+
+		closes := 0
+		for strings.HasSuffix(operand, ")") && strings.Count(operand, ")") > strings.Count(operand, "(") {
+			if depth == 0 {
+				return nil, errUnexpected
+			}
+
+			operand, closes, depth = operand[:len(operand)-1], closes+1, depth-1
+		}
+
+		tokens = append(tokens, &token{kind: tokenRule, value: strings.TrimSpace(operand), offset: start})
+		for i := 0; i < closes; i++ {
+			tokens = append(tokens, &token{kind: tokenClose, value: ")", offset: start + len(operand) + i})
+		}
+
+		if kind == -1 {
+			break
+		}
+
+		tokens = append(tokens, &token{kind: kind, value: strings.TrimSpace(value[end : end+len(or)]), offset: end + 1})
+		offset = end + len(or)
+	}
+
+	if depth > 0 {
+		return nil, errMissingClose
+	}
+
+	return tokens, nil
+}
+
+// parser builds the rules of a rule string
+// & binds stronger than | and parentheses group rules:
+//   - a | b & c = a | (b & c)
+//   - (a | b) & c
+//
+// the top level alternatives are returned as rules - AND and grouped alternatives as expressions
+type parser struct {
+	config *Config
+	tokens []*token
+	pos    int
+}
+
+func (config *Config) parseRules(value string) ([]rule.Rule, error) {
+	rules, err := config.parse(value)
+	if errors.Is(err, errMissingRule) || errors.Is(err, errMissingClose) || errors.Is(err, errUnexpected) {
+		return nil, fmt.Errorf("rules %s failed with %w", value, err)
+	}
+
+	return rules, err
+}
+
+func (config *Config) parse(value string) ([]rule.Rule, error) {
+	tokens, err := tokenize(value)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{config: config, tokens: tokens}
+
+	var rules []rule.Rule
+	if rules, err = p.parseOr(); err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, errUnexpected
+	}
+
+	return rules, nil
+}
+
+func (p *parser) peek() tokenKind {
+	if p.pos >= len(p.tokens) {
+		return -1
+	}
+
+	return p.tokens[p.pos].kind
+}
+
+func (p *parser) parseOr() ([]rule.Rule, error) {
+	operand, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []rule.Rule{operand}
+	for p.peek() == tokenOr {
+		p.pos++
+
+		if operand, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, operand)
+	}
+
+	return rules, nil
+}
+
+func (p *parser) parseAnd() (rule.Rule, error) {
+	operand, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek() != tokenAnd {
+		return operand, nil
+	}
+
+	operands := []rule.Rule{operand}
+	for p.peek() == tokenAnd {
+		p.pos++
+
+		if operand, err = p.parsePrimary(); err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, operand)
+	}
+
+	if err = combinable(operands); err != nil {
+		return nil, err
+	}
+
+	return rule.NewAnd(operands), nil
+}
+
+func (p *parser) parsePrimary() (rule.Rule, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errMissingRule
+	}
+
+	t := p.tokens[p.pos]
+	p.pos++
+
+	switch t.kind {
+	case tokenOpen:
+		operands, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != tokenClose {
+			return nil, errMissingClose
+		}
+
+		p.pos++
+
+		if len(operands) == 1 {
+			return operands[0], nil
+		}
+
+		if err = combinable(operands); err != nil {
+			return nil, err
+		}
+
+		return rule.NewOr(operands), nil
+	case tokenRule:
+		return p.config.newRule(t.value)
+	default:
+		return nil, errMissingRule
+	}
+}
+
+// combinable checks that no operand is an exclusive rule like exists
+// exclusive rules are validated by the linter on their own
+func combinable(operands []rule.Rule) error {
+	for _, operand := range operands {
+		if operand.GetExclusive() {
+			return fmt.Errorf("rule %s can't be combined with & or grouped", operand.GetName())
+		}
+	}
+
+	return nil
+}
+
+// newRule returns a copy of the named rule with its parameters
+func (config *Config) newRule(value string) (rule.Rule, error) {
+	if value == "" {
+		return nil, errMissingRule
+	}
+
+	ruleSplit := strings.SplitN(value, ":", 2)
+	ruleName := ruleSplit[0]
+
+	r, ok := config.getRule(ruleName)
+	if !ok {
+		return nil, fmt.Errorf("rule %s not exists", ruleName)
+	}
+
+	r = r.Copy()
+	if err := r.SetParameters(ruleSplit[1:]); err != nil {
+		return nil, fmt.Errorf("rule %s failed with %s", ruleName, err.Error())
+	}
+
+	return r, nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []*struct {
+		value    string
+		expected []string
+		err      error
+	}{
+		{value: "kebab-case", expected: []string{"kebabcase"}},
+		{value: "kebab-case | exists:1", expected: []string{"kebabcase", "exists:1 (found 0)"}},
+		{value: "kebab-case & length:1-30", expected: []string{"kebabcase & length:1-30"}},
+		{value: "camelCase | kebab-case & regex:!test", expected: []string{"camelcase", "kebabcase & regex:!test"}},
+		{value: "(camelCase | kebab-case) & length:3", expected: []string{"(camelcase | kebabcase) & length:3"}},
+		{value: "((kebab-case)) | exists", expected: []string{"kebabcase", "exists:1-32767 (found 0)"}},
+		{value: "regex:(foo|bar) & lowercase", expected: []string{"regex:(foo|bar) & lowercase"}},
+		{value: "(regex:(foo|bar)) & lowercase", expected: []string{"regex:(foo|bar) & lowercase"}},
+		{value: "(kebab-case & length:3", err: errMissingClose},
+		{value: "kebab-case) & length:3", err: errUnexpected},
+		{value: "kebab-case & ", err: errMissingRule},
+		{value: "() | kebab-case", err: errMissingRule},
+	}
+
+	config := &Config{RWMutex: new(sync.RWMutex)}
+
+	i := 0
+	for _, test := range tests {
+		rules, err := config.parseRules(test.value)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Test %d failed with unmatched error - %v", i, err)
+			return
+		}
+
+		messages := make([]string, 0, len(rules))
+		for _, r := range rules {
+			messages = append(messages, r.GetErrorMessage())
+		}
+
+		if len(messages) != len(test.expected) {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, messages)
+			return
+		}
+
+		for j := range messages {
+			if messages[j] != test.expected[j] {
+				t.Errorf("Test %d failed with unmatched return value - %+v", i, messages)
+				return
+			}
+		}
+
+		i++
+	}
+
+	for _, value := range []string{"kebab-case & exists:1", "(exists | kebab-case)", "kebab-case & unknown"} {
+		if _, err := config.parseRules(value); err == nil {
+			t.Errorf("Test %s failed without error", value)
+		}
+	}
+}
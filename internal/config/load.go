@@ -0,0 +1,223 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+	"go.yaml.in/yaml/v3"
+)
+
+const presetPrefix = "preset:"
+
+//go:embed presets/*.yml
+var presets embed.FS
+
+// ReadFileFunc reads the config file with the given name
+type ReadFileFunc func(name string) ([]byte, error)
+
+// Extends is a single config file or a list of config files
+type Extends []string
+
+func (extends *Extends) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*extends = Extends{value.Value}
+		return nil
+	}
+
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return fmt.Errorf("extends must be a string or a list of strings")
+	}
+
+	*extends = list
+	return nil
+}
+
+// Load reads the config file and merges all extended configs
+func Load(read ReadFileFunc, name string) (*Config, error) {
+	return load(read, name, nil, nil, nil)
+}
+
+// Parse parses the config data and merges all extended configs
+// extended config files are relative to the given name
+func Parse(read ReadFileFunc, name string, data []byte) (*Config, error) {
+	return load(read, name, data, nil, nil)
+}
+
+// ParseWithRules parses the config data like Parse
+// the custom rules are valid rule names of the config files
+func ParseWithRules(read ReadFileFunc, name string, data []byte, rules map[string]rule.Rule) (*Config, error) {
+	return load(read, name, data, rules, nil)
+}
+
+func load(read ReadFileFunc, name string, data []byte, rules map[string]rule.Rule, stack []string) (*Config, error) {
+	var err error
+
+	if slices.Contains(stack, name) {
+		return nil, fmt.Errorf("config %s extends itself: %s", name, strings.Join(append(stack, name), " -> "))
+	}
+	stack = append(stack, name)
+
+	if data == nil {
+		if data, err = readConfig(read, name); err != nil {
+			return nil, err
+		}
+	}
+
+	tmpConfig := NewConfig(nil, nil)
+	tmpConfig.Rules = rules
+	if problems := tmpConfig.Validate(data); len(problems) > 0 {
+		return nil, &ValidationError{Name: name, Problems: problems}
+	}
+
+	if err = yaml.Unmarshal(data, tmpConfig); err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err.Error())
+	}
+
+	config := NewConfig(make(Ls), make([]string, 0))
+	for _, extends := range tmpConfig.Extends {
+		var extendsConfig *Config
+		if extendsConfig, err = load(read, resolveExtends(name, extends), nil, rules, stack); err != nil {
+			return nil, err
+		}
+
+		config.Merge(extendsConfig)
+	}
+
+	tmpConfig.Origins = make(map[string]map[string]string)
+	setOrigins(tmpConfig.Origins, "", tmpConfig.Ls, name)
+	if !strings.HasPrefix(name, presetPrefix) {
+		tmpConfig.Files = []string{name}
+	}
+
+	config.Merge(tmpConfig)
+
+	return config, nil
+}
+
+func readConfig(read ReadFileFunc, name string) ([]byte, error) {
+	if preset, ok := strings.CutPrefix(name, presetPrefix); ok {
+		data, err := presets.ReadFile(fmt.Sprintf("presets/%s.yml", preset))
+		if err != nil {
+			return nil, fmt.Errorf("preset %s not exists", preset)
+		}
+
+		return data, nil
+	}
+
+	return read(name)
+}
+
+// resolveExtends resolves the extended config relative to the extending config
+func resolveExtends(name string, extends string) string {
+	if strings.HasPrefix(extends, presetPrefix) || filepath.IsAbs(extends) || path.IsAbs(extends) {
+		return extends
+	}
+
+	if strings.HasPrefix(name, presetPrefix) {
+		return extends
+	}
+
+	return path.Join(path.Dir(filepath.ToSlash(name)), filepath.ToSlash(extends))
+}
+
+// Merge deep merges the source config into the config
+// ls directories are merged recursively - extensions of the source config take precedence
+func (config *Config) Merge(source *Config) {
+	config.Lock()
+	defer config.Unlock()
+
+	if config.Ls == nil {
+		config.Ls = make(Ls)
+	}
+
+	mergeLs(config.Ls, source.GetLs())
+
+	config.Ignore = append(config.Ignore, source.GetIgnore()...)
+	slices.Sort(config.Ignore)
+	config.Ignore = slices.Compact(config.Ignore)
+
+	config.Exceptions = mergeExceptions(config.Exceptions, source.GetExceptions())
+
+	config.UseGitignore = config.UseGitignore || source.GetUseGitignore()
+	config.NestedConfig = config.NestedConfig || source.GetNestedConfig()
+
+	if config.Origins == nil {
+		config.Origins = make(map[string]map[string]string)
+	}
+
+	for _, file := range source.GetFiles() {
+		if !slices.Contains(config.Files, file) {
+			config.Files = append(config.Files, file)
+		}
+	}
+
+	for key, exts := range source.GetOrigins() {
+		if config.Origins[key] == nil {
+			config.Origins[key] = make(map[string]string, len(exts))
+		}
+
+		for ext, origin := range exts {
+			config.Origins[key][ext] = origin
+		}
+	}
+}
+
+func mergeLs(target Ls, source Ls) {
+	for key, value := range source {
+		sourceLs, sourceIsLs := toLs(value)
+		targetLs, targetIsLs := toLs(target[key])
+
+		// rule sets replace the rules like rule strings
+		switch {
+		case isRuleSet(value) || isRuleSet(target[key]):
+			target[key] = value
+		case sourceIsLs && targetIsLs:
+			mergeLs(targetLs, sourceLs)
+			target[key] = targetLs
+		case sourceIsLs:
+			copyLs := make(Ls, len(sourceLs))
+			mergeLs(copyLs, sourceLs)
+			target[key] = copyLs
+		default:
+			target[key] = value
+		}
+	}
+}
+
+func toLs(value interface{}) (Ls, bool) {
+	switch v := value.(type) {
+	case Ls:
+		return v, true
+	case map[string]interface{}:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// setOrigins sets the origin of all extensions of the ls tree
+func setOrigins(origins map[string]map[string]string, key string, ls Ls, origin string) {
+	for k, v := range ls {
+		if childLs, ok := toLs(v); ok && !isRuleSet(v) {
+			childKey := k
+			if key != "" {
+				childKey = fmt.Sprintf("%s%s%s", key, sep, k)
+			}
+
+			setOrigins(origins, childKey, childLs, origin)
+			continue
+		}
+
+		if origins[key] == nil {
+			origins[key] = make(map[string]string)
+		}
+
+		origins[key][k] = origin
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	filesystem := fstest.MapFS{
+		".ls-lint.yml": &fstest.MapFile{Data: []byte(`extends:
+  - configs/base.yml
+ls:
+  .png: kebab-case
+  src:
+    .ts: camelCase
+ignore:
+  - dist
+`)},
+		"configs/base.yml": &fstest.MapFile{Data: []byte(`extends: preset:go
+ls:
+  .png: snake_case
+  src:
+    .js: kebab-case
+    .ts: PascalCase
+ignore:
+  - node_modules
+`)},
+		"cycle.yml":           &fstest.MapFile{Data: []byte("extends: configs/cycle.yml\n")},
+		"configs/cycle.yml":   &fstest.MapFile{Data: []byte("extends: ../cycle.yml\n")},
+		"preset.yml":          &fstest.MapFile{Data: []byte("extends: preset:unknown\n")},
+		"invalid.yml":         &fstest.MapFile{Data: []byte("extends: configs/invalid.yml\n")},
+		"configs/invalid.yml": &fstest.MapFile{Data: []byte("ls:\n  .js: kebabcas\nignores:\n  - dist\n")},
+	}
+
+	read := func(name string) ([]byte, error) {
+		return fs.ReadFile(filesystem, name)
+	}
+
+	config, err := Load(read, ".ls-lint.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedLs := Ls{
+		".dir": "lowercase",
+		".go":  "snake_case",
+		".png": "kebab-case",
+		"src": Ls{
+			".js": "kebab-case",
+			".ts": "camelCase",
+		},
+	}
+
+	if !reflect.DeepEqual(config.GetLs(), expectedLs) {
+		t.Errorf("unmatched ls - %+v", config.GetLs())
+	}
+
+	expectedIgnore := []string{"**/testdata", ".git", "dist", "node_modules", "vendor"}
+	if !reflect.DeepEqual(config.GetIgnore(), expectedIgnore) {
+		t.Errorf("unmatched ignore - %+v", config.GetIgnore())
+	}
+
+	expectedOrigins := map[string]map[string]string{
+		"": {
+			".dir": "preset:go",
+			".go":  "preset:go",
+			".png": ".ls-lint.yml",
+		},
+		"src": {
+			".js": "configs/base.yml",
+			".ts": ".ls-lint.yml",
+		},
+	}
+
+	if !reflect.DeepEqual(config.GetOrigins(), expectedOrigins) {
+		t.Errorf("unmatched origins - %+v", config.GetOrigins())
+	}
+
+	if expectedFiles := []string{"configs/base.yml", ".ls-lint.yml"}; !reflect.DeepEqual(config.GetFiles(), expectedFiles) {
+		t.Errorf("unmatched files - %+v", config.GetFiles())
+	}
+
+	for _, name := range []string{"cycle.yml", "preset.yml", "not_exists.yml"} {
+		if _, err = Load(read, name); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	expectedErr := "configs/invalid.yml:2:8: rule kebabcas not exists - did you mean kebabcase?\nconfigs/invalid.yml:3:1: unknown key ignores - did you mean ignore?"
+	if _, err = Load(read, "invalid.yml"); err == nil || err.Error() != expectedErr {
+		t.Errorf("unmatched validation error - %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const (
+	objectRule     = "rule"
+	objectParams   = "params"
+	objectMessage  = "message"
+	objectSeverity = "severity"
+)
+
+// parseRuleList parses a list of rule strings and rule objects
+// all rules of the list are alternatives like rules separated by " | "
+//
+//	.ts:
+//	  - kebab-case
+//	  - rule: regex
+//	    pattern: ^[a-z]+ | [0-9]+$
+//	    message: use lowercase letters or digits
+//	    severity: warning
+func (config *Config) parseRuleList(list []interface{}) ([]rule.Rule, error) {
+	rules := make([]rule.Rule, 0, len(list))
+	for _, item := range list {
+		switch v := item.(type) {
+		case string:
+			parsedRules, err := config.parseRules(v)
+			if err != nil {
+				return nil, err
+			}
+
+			rules = append(rules, parsedRules...)
+		default:
+			object, ok := toLs(v)
+			if !ok {
+				return nil, fmt.Errorf("rule must be a rule string or a rule object, found %T", item)
+			}
+
+			r, err := config.parseRuleObject(object)
+			if err != nil {
+				return nil, err
+			}
+
+			rules = append(rules, r)
+		}
+	}
+
+	return rules, nil
+}
+
+// parseRuleObject returns the rule of a rule object
+// params are passed like the parameters of the rule string syntax - all other options are named options of the rule
+func (config *Config) parseRuleObject(object Ls) (rule.Rule, error) {
+	ruleName, ok := object[objectRule].(string)
+	if !ok {
+		return nil, fmt.Errorf("rule object must have a rule name")
+	}
+
+	r, ok := config.getRule(ruleName)
+	if !ok {
+		return nil, fmt.Errorf("rule %s not exists", ruleName)
+	}
+
+	r = r.Copy()
+
+	message, severity := "", ""
+	options := make(rule.Options)
+	for key, value := range object {
+		switch key {
+		case objectRule:
+			continue
+		case objectMessage:
+			message = optionValue(value)
+		case objectSeverity:
+			severity = optionValue(value)
+		default:
+			options[key] = optionValue(value)
+		}
+	}
+
+	if severity != "" && !slices.Contains(rule.Severities, severity) {
+		return nil, fmt.Errorf("severity %s not exists", severity)
+	}
+
+	if err := setOptions(r, options); err != nil {
+		return nil, fmt.Errorf("rule %s failed with %s", ruleName, err.Error())
+	}
+
+	if message == "" && severity == "" {
+		return r, nil
+	}
+
+	return rule.NewAnnotated(r, message, severity), nil
+}
+
+func setOptions(r rule.Rule, options rule.Options) error {
+	if params, ok := options[objectParams]; ok {
+		if len(options) > 1 {
+			return fmt.Errorf("params can't be combined with named options")
+		}
+
+		return r.SetParameters([]string{params})
+	}
+
+	if optionsSetter, ok := r.(rule.OptionsSetter); ok {
+		return optionsSetter.SetOptions(options)
+	}
+
+	if len(options) > 0 {
+		return fmt.Errorf("option %s not exists", slices.Sorted(maps.Keys(options))[0])
+	}
+
+	return r.SetParameters([]string{})
+}
+
+// optionValue returns the string of a yaml scalar - lists are joined by comma
+func optionValue(value interface{}) string {
+	if list, ok := value.([]interface{}); ok {
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			values = append(values, fmt.Sprint(item))
+		}
+
+		return strings.Join(values, ",")
+	}
+
+	return fmt.Sprint(value)
+}
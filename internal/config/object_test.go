@@ -0,0 +1,53 @@
+package config
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+func TestParseRuleList(t *testing.T) {
+	config := &Config{RWMutex: new(sync.RWMutex)}
+
+	rules, err := config.parseRuleList([]interface{}{
+		"kebab-case | camelCase",
+		map[string]interface{}{"rule": "regex", "pattern": "^[a-z]+ | [0-9]+$", "message": "use lowercase letters or digits", "severity": "warning"},
+		map[string]interface{}{"rule": "length", "min": 1, "max": 30},
+		map[string]interface{}{"rule": "portable", "checks": []interface{}{"windows", "trailing"}},
+		map[string]interface{}{"rule": "exists", "params": "1-4"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"kebabcase", "camelcase", "use lowercase letters or digits", "length:1-30", "portable:windows,trailing", "exists:1-4 (found 0)"}
+	if len(rules) != len(expected) {
+		t.Fatalf("Test failed with unmatched rules - %+v", rules)
+	}
+
+	for i, r := range rules {
+		if r.GetErrorMessage() != expected[i] {
+			t.Errorf("Test %d failed with unmatched return value - %s", i, r.GetErrorMessage())
+		}
+	}
+
+	if annotated, ok := rules[2].(*rule.Annotated); !ok || annotated.GetSeverity() != rule.SeverityWarning || annotated.GetParameters()[0] != "^[a-z]+ | [0-9]+$" {
+		t.Errorf("Test failed with unmatched annotated rule - %+v", rules[2])
+	}
+
+	failures := [][]interface{}{
+		{1},
+		{map[string]interface{}{"pattern": "^[a-z]+$"}},
+		{map[string]interface{}{"rule": "unknown"}},
+		{map[string]interface{}{"rule": "kebab-case", "pattern": "^[a-z]+$"}},
+		{map[string]interface{}{"rule": "regex", "params": "^[a-z]+$", "negate": true}},
+		{map[string]interface{}{"rule": "regex", "pattern": "^[a-z]+$", "severity": "fatal"}},
+	}
+
+	for _, failure := range failures {
+		if _, err = config.parseRuleList(failure); err == nil {
+			t.Errorf("Test %+v failed without error", failure)
+		}
+	}
+}
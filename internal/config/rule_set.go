@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const (
+	ruleSetRules   = "rules"
+	ruleSetMessage = "message"
+	ruleSetURL     = "url"
+)
+
+// ruleSet is a mapping of rules with a help message and a documentation url
+//
+//	.ts:
+//	  rules: kebab-case
+//	  message: components are named after their kebab-case tag
+//	  url: https://example.com/naming#components
+type ruleSet struct {
+	rules   interface{}
+	message string
+	url     string
+}
+
+// toRuleSet returns the rule set of the value or nil if the value is no rule set
+// mappings are rule sets if their rules are not a mapping - all other mappings are directories
+func toRuleSet(value interface{}) (*ruleSet, error) {
+	list, ok := toLs(value)
+	if !ok {
+		return nil, nil
+	}
+
+	rules, ok := list[ruleSetRules]
+	if !ok {
+		return nil, nil
+	}
+
+	if _, isDir := toLs(rules); isDir {
+		return nil, nil
+	}
+
+	set := &ruleSet{rules: rules}
+	for key, value := range list {
+		switch key {
+		case ruleSetRules:
+			continue
+		case ruleSetMessage, ruleSetURL:
+			text, isString := value.(string)
+			if !isString {
+				return nil, fmt.Errorf("%s must be a string, found %T", key, value)
+			}
+
+			if key == ruleSetMessage {
+				set.message = text
+				continue
+			}
+
+			set.url = text
+		default:
+			return nil, fmt.Errorf("rule set option %s not exists", key)
+		}
+	}
+
+	return set, nil
+}
+
+// isRuleSet returns true if the value is a rule set and no directory
+func isRuleSet(value interface{}) bool {
+	set, err := toRuleSet(value)
+	return set != nil || err != nil
+}
+
+// document adds the message and url of the rule set to the rules
+func (set *ruleSet) document(rules []rule.Rule) []rule.Rule {
+	if set.message == "" && set.url == "" {
+		return rules
+	}
+
+	documented := make([]rule.Rule, 0, len(rules))
+	for _, r := range rules {
+		documented = append(documented, rule.Document(r, set.message, set.url))
+	}
+
+	return documented
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+func TestRuleSet(t *testing.T) {
+	config := &Config{RWMutex: new(sync.RWMutex)}
+
+	index, err := config.GetIndex(Ls{
+		"components": Ls{
+			".ts": Ls{
+				"rules":   "kebab-case | regex:^index$",
+				"message": "components are named after their tag",
+				"url":     "https://example.com/naming",
+			},
+			".js": Ls{
+				"rules": []interface{}{
+					"kebab-case",
+					map[string]interface{}{"rule": "regex", "pattern": "^index$", "severity": "warning"},
+				},
+				"url": "https://example.com/naming",
+			},
+			"rules": Ls{
+				".ts": "camelCase",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*struct {
+		key   string
+		ext   string
+		rules int
+		help  string
+		url   string
+	}{
+		{key: "components", ext: ".ts", rules: 2, help: "components are named after their tag", url: "https://example.com/naming"},
+		{key: "components", ext: ".js", rules: 2, url: "https://example.com/naming"},
+		{key: "components/rules", ext: ".ts", rules: 1},
+	}
+
+	var i = 0
+	for _, test := range tests {
+		rules := index[test.key][test.ext]
+		if len(rules) != test.rules {
+			t.Errorf("Test %d failed with unmatched rules - %+v", i, rules)
+		}
+
+		for _, r := range rules {
+			annotated, ok := r.(*rule.Annotated)
+			if !ok {
+				if test.help != "" || test.url != "" {
+					t.Errorf("Test %d failed without annotated rule - %+v", i, r)
+				}
+
+				continue
+			}
+
+			if annotated.GetHelp() != test.help || annotated.GetURL() != test.url {
+				t.Errorf("Test %d failed with unmatched annotations - %s %s", i, annotated.GetHelp(), annotated.GetURL())
+			}
+		}
+
+		i++
+	}
+
+	// the severity of rule objects is kept
+	if rule.SeverityOf(index["components"][".js"][1]) != rule.SeverityWarning {
+		t.Errorf("Test failed with unmatched severity - %+v", index["components"][".js"][1])
+	}
+
+	failures := []Ls{
+		{".ts": Ls{"rules": "kebab-case", "mesage": "typo"}},
+		{".ts": Ls{"rules": "kebab-case", "url": 1}},
+		{".ts": Ls{"rules": 1}},
+	}
+
+	for _, failure := range failures {
+		if _, err = config.GetIndex(failure); err == nil {
+			t.Errorf("Test %+v failed without error", failure)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+// TestSchema checks that the shipped json schema knows all config keys and rules
+func TestSchema(t *testing.T) {
+	data, err := os.ReadFile("../../schema/ls_lint.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema struct {
+		Properties  map[string]any `json:"properties"`
+		Definitions struct {
+			Rules struct {
+				AnyOf []struct {
+					Enum []string `json:"enum"`
+				} `json:"anyOf"`
+			} `json:"rules"`
+		} `json:"definitions"`
+	}
+
+	if err = json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	if properties := slices.Sorted(maps.Keys(schema.Properties)); !slices.Equal(properties, slices.Sorted(slices.Values(keys))) {
+		t.Errorf("unmatched schema properties - %+v", properties)
+	}
+
+	enum := schema.Definitions.Rules.AnyOf[0].Enum
+	for name := range rule.Rules {
+		if !slices.Contains(enum, name) && !slices.Contains(enum, name+":") {
+			t.Errorf("rule %s is missing in the schema", name)
+		}
+	}
+}
@@ -0,0 +1,501 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+	"go.yaml.in/yaml/v3"
+)
+
+// Problem is an invalid config value at a position of the config file
+// lines and columns start at 1 like the yaml nodes
+type Problem struct {
+	Line    int
+	Column  int
+	Length  int
+	Message string
+}
+
+func (problem *Problem) Error() string {
+	return fmt.Sprintf("%d:%d: %s", problem.Line, problem.Column, problem.Message)
+}
+
+// ValidationError contains all problems of a config file
+type ValidationError struct {
+	Name     string
+	Problems []*Problem
+}
+
+func (err *ValidationError) Error() string {
+	lines := make([]string, 0, len(err.Problems))
+	for _, problem := range err.Problems {
+		lines = append(lines, fmt.Sprintf("%s:%s", err.Name, problem.Error()))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+const (
+	tagStr   = "!!str"
+	tagBool  = "!!bool"
+	tagNull  = "!!null"
+	tagMerge = "!!merge"
+)
+
+var (
+	yamlLine = regexp.MustCompile(`^yaml: line ([0-9]+): (.*)$`)
+
+	keys = []string{"extends", "ls", "ignore", "exceptions", "use_gitignore", "nested_config"}
+)
+
+// Validate walks the yaml nodes of the config data and reports all problems at once
+// unknown keys, type mismatches, unknown rules and rules with invalid parameters
+func (config *Config) Validate(data []byte) []*Problem {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return []*Problem{syntaxProblem(err)}
+	}
+
+	problems := make([]*Problem, 0)
+	if len(document.Content) == 0 {
+		return problems
+	}
+
+	root := resolve(document.Content[0])
+	switch {
+	case root.ShortTag() == tagNull:
+		return problems
+	case root.Kind != yaml.MappingNode:
+		return append(problems, typeProblem(root, "config", "a mapping"))
+	}
+
+	for _, pair := range mappingPairs(root) {
+		key, value := pair[0], pair[1]
+
+		switch key.Value {
+		case "extends":
+			if value.Kind == yaml.ScalarNode && value.ShortTag() == tagStr {
+				continue
+			}
+
+			problems = validateStrings(value, key.Value, problems)
+		case "ls":
+			if value.ShortTag() == tagNull {
+				continue
+			}
+
+			if value.Kind != yaml.MappingNode {
+				problems = append(problems, typeProblem(value, key.Value, "a mapping"))
+				continue
+			}
+
+			problems = config.validateLs(value, problems)
+		case "ignore":
+			problems = validateStrings(value, key.Value, problems)
+		case "exceptions":
+			if value.ShortTag() == tagNull {
+				continue
+			}
+
+			if value.Kind != yaml.MappingNode {
+				problems = append(problems, typeProblem(value, key.Value, "a mapping"))
+				continue
+			}
+
+			problems = config.validateExceptions(value, problems)
+		case "use_gitignore", "nested_config":
+			if value.Kind != yaml.ScalarNode || value.ShortTag() != tagBool {
+				problems = append(problems, typeProblem(value, key.Value, "a boolean"))
+			}
+		default:
+			problems = append(problems, &Problem{
+				Line:    key.Line,
+				Column:  key.Column,
+				Length:  len(key.Value),
+				Message: fmt.Sprintf("unknown key %s%s", key.Value, suggestion(key.Value, keys)),
+			})
+		}
+	}
+
+	// anchored values are validated for every alias
+	seen := make(map[Problem]bool, len(problems))
+	return slices.DeleteFunc(problems, func(problem *Problem) bool {
+		duplicate := seen[*problem]
+		seen[*problem] = true
+
+		return duplicate
+	})
+}
+
+func (config *Config) validateExceptions(node *yaml.Node, problems []*Problem) []*Problem {
+	for _, pair := range mappingPairs(node) {
+		key, value := pair[0], pair[1]
+
+		if value.Kind != yaml.MappingNode {
+			problems = append(problems, typeProblem(value, key.Value, "a mapping"))
+			continue
+		}
+
+		exceptionProblems := len(problems)
+		for _, exceptionPair := range mappingPairs(value) {
+			exceptionKey, exceptionValue := exceptionPair[0], exceptionPair[1]
+
+			switch exceptionKey.Value {
+			case "rules":
+				problems = validateStrings(exceptionValue, exceptionKey.Value, problems)
+			case "reason":
+				if exceptionValue.Kind != yaml.ScalarNode || exceptionValue.ShortTag() != tagStr {
+					problems = append(problems, typeProblem(exceptionValue, exceptionKey.Value, "a string"))
+				}
+			default:
+				problems = append(problems, &Problem{
+					Line:    exceptionKey.Line,
+					Column:  exceptionKey.Column,
+					Length:  len(exceptionKey.Value),
+					Message: fmt.Sprintf("exception option %s not exists%s", exceptionKey.Value, suggestion(exceptionKey.Value, []string{"rules", "reason"})),
+				})
+			}
+		}
+
+		if len(problems) > exceptionProblems {
+			continue
+		}
+
+		var exception Exception
+		if err := value.Decode(&exception); err != nil {
+			problems = append(problems, &Problem{Line: value.Line, Column: value.Column, Message: err.Error()})
+			continue
+		}
+
+		if _, err := config.GetExceptionRules(&exception); err != nil {
+			problems = append(problems, &Problem{
+				Line:    key.Line,
+				Column:  key.Column,
+				Length:  len(key.Value),
+				Message: fmt.Sprintf("exception %s failed with %s", key.Value, err.Error()),
+			})
+		}
+	}
+
+	return problems
+}
+
+func (config *Config) validateLs(node *yaml.Node, problems []*Problem) []*Problem {
+	for _, pair := range mappingPairs(node) {
+		key, value := pair[0], pair[1]
+
+		switch {
+		case value.Kind == yaml.MappingNode && isRuleSetNode(value):
+			problems = config.validateRuleSet(value, problems)
+		case value.Kind == yaml.MappingNode:
+			problems = config.validateLs(value, problems)
+		case value.Kind == yaml.ScalarNode && value.ShortTag() == tagStr:
+			problems = config.validateRules(value, problems)
+		case value.Kind == yaml.SequenceNode:
+			problems = config.validateRuleList(value, problems)
+		case value.ShortTag() == tagNull:
+			continue
+		default:
+			problems = append(problems, typeProblem(value, key.Value, "a rule string, a list of rules or a mapping"))
+		}
+	}
+
+	return problems
+}
+
+// isRuleSetNode returns true if the mapping has rules which are not a mapping
+func isRuleSetNode(node *yaml.Node) bool {
+	for _, pair := range mappingPairs(node) {
+		if pair[0].Value == ruleSetRules {
+			return pair[1].Kind != yaml.MappingNode
+		}
+	}
+
+	return false
+}
+
+func (config *Config) validateRuleSet(node *yaml.Node, problems []*Problem) []*Problem {
+	for _, pair := range mappingPairs(node) {
+		key, value := pair[0], pair[1]
+
+		switch key.Value {
+		case ruleSetRules:
+			switch {
+			case value.Kind == yaml.ScalarNode && value.ShortTag() == tagStr:
+				problems = config.validateRules(value, problems)
+			case value.Kind == yaml.SequenceNode:
+				problems = config.validateRuleList(value, problems)
+			default:
+				problems = append(problems, typeProblem(value, key.Value, "a rule string or a list of rules"))
+			}
+		case ruleSetMessage, ruleSetURL:
+			if value.Kind != yaml.ScalarNode || value.ShortTag() != tagStr {
+				problems = append(problems, typeProblem(value, key.Value, "a string"))
+			}
+		default:
+			problems = append(problems, &Problem{
+				Line:    key.Line,
+				Column:  key.Column,
+				Length:  len(key.Value),
+				Message: fmt.Sprintf("rule set option %s not exists%s", key.Value, suggestion(key.Value, []string{ruleSetRules, ruleSetMessage, ruleSetURL})),
+			})
+		}
+	}
+
+	return problems
+}
+
+func (config *Config) validateRuleList(node *yaml.Node, problems []*Problem) []*Problem {
+	for _, item := range node.Content {
+		item = resolve(item)
+
+		switch {
+		case item.Kind == yaml.ScalarNode && item.ShortTag() == tagStr:
+			problems = config.validateRules(item, problems)
+		case item.Kind == yaml.MappingNode:
+			problems = config.validateRuleObject(item, problems)
+		default:
+			problems = append(problems, typeProblem(item, "rule", "a rule string or a rule object"))
+		}
+	}
+
+	return problems
+}
+
+func (config *Config) validateRuleObject(node *yaml.Node, problems []*Problem) []*Problem {
+	for _, pair := range mappingPairs(node) {
+		key, value := pair[0], pair[1]
+		if key.Value != objectRule || value.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		if _, ok := config.getRule(value.Value); !ok {
+			return append(problems, &Problem{
+				Line:    value.Line,
+				Column:  value.Column,
+				Length:  len(value.Value),
+				Message: fmt.Sprintf("rule %s not exists%s", value.Value, suggestion(value.Value, config.ruleNames())),
+			})
+		}
+	}
+
+	var object Ls
+	if err := node.Decode(&object); err != nil {
+		return append(problems, &Problem{Line: node.Line, Column: node.Column, Message: err.Error()})
+	}
+
+	if _, err := config.parseRuleObject(object); err != nil {
+		problems = append(problems, &Problem{Line: node.Line, Column: node.Column, Message: err.Error()})
+	}
+
+	return problems
+}
+
+func (config *Config) validateRules(node *yaml.Node, problems []*Problem) []*Problem {
+	column := node.Column
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		column++
+	}
+
+	tokens, err := tokenize(node.Value)
+	if err != nil {
+		return append(problems, &Problem{
+			Line:    node.Line,
+			Column:  column,
+			Length:  len(node.Value),
+			Message: fmt.Sprintf("rules failed with %s", err.Error()),
+		})
+	}
+
+	ruleProblems := len(problems)
+	for _, t := range tokens {
+		if t.kind != tokenRule || t.value == "" {
+			continue
+		}
+
+		ruleSplit := strings.SplitN(t.value, ":", 2)
+		problem := &Problem{Line: node.Line, Column: column + t.offset, Length: len(t.value)}
+
+		r, ok := config.getRule(ruleSplit[0])
+		if !ok {
+			problem.Message = fmt.Sprintf("rule %s not exists%s", ruleSplit[0], suggestion(ruleSplit[0], config.ruleNames()))
+			problems = append(problems, problem)
+			continue
+		}
+
+		if err = r.Copy().SetParameters(ruleSplit[1:]); err != nil {
+			problem.Message = fmt.Sprintf("rule %s failed with %s", ruleSplit[0], err.Error())
+			problems = append(problems, problem)
+		}
+	}
+
+	// missing rules, unbalanced parentheses and combined exists rules
+	if len(problems) == ruleProblems {
+		if _, err = config.parse(node.Value); err != nil {
+			problems = append(problems, &Problem{
+				Line:    node.Line,
+				Column:  column,
+				Length:  len(node.Value),
+				Message: fmt.Sprintf("rules failed with %s", err.Error()),
+			})
+		}
+	}
+
+	return problems
+}
+
+func (config *Config) ruleNames() []string {
+	names := slices.Collect(maps.Keys(rule.Rules))
+	for name := range config.GetRules() {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func validateStrings(node *yaml.Node, key string, problems []*Problem) []*Problem {
+	if node.ShortTag() == tagNull {
+		return problems
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return append(problems, typeProblem(node, key, "a list of strings"))
+	}
+
+	for _, item := range node.Content {
+		if item = resolve(item); item.Kind != yaml.ScalarNode || item.ShortTag() != tagStr {
+			problems = append(problems, typeProblem(item, key, "a string"))
+		}
+	}
+
+	return problems
+}
+
+// resolve returns the anchored node of an alias
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node
+}
+
+// mappingPairs returns the key value pairs of the mapping with resolved aliases
+// the pairs of merged mappings (<<) are added unless the mapping or a previous merge has the key
+func mappingPairs(node *yaml.Node) [][2]*yaml.Node {
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	merges := make([]*yaml.Node, 0)
+	keys := make(map[string]bool, len(node.Content)/2)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], resolve(node.Content[i+1])
+
+		if key.ShortTag() != tagMerge {
+			pairs = append(pairs, [2]*yaml.Node{key, value})
+			keys[key.Value] = true
+			continue
+		}
+
+		switch value.Kind {
+		case yaml.MappingNode:
+			merges = append(merges, value)
+		case yaml.SequenceNode:
+			for _, item := range value.Content {
+				merges = append(merges, resolve(item))
+			}
+		}
+	}
+
+	for _, merge := range merges {
+		if merge.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for _, pair := range mappingPairs(merge) {
+			if !keys[pair[0].Value] {
+				pairs = append(pairs, pair)
+				keys[pair[0].Value] = true
+			}
+		}
+	}
+
+	return pairs
+}
+
+func typeProblem(node *yaml.Node, key string, expected string) *Problem {
+	found := node.ShortTag()
+	switch node.Kind {
+	case yaml.MappingNode:
+		found = "mapping"
+	case yaml.SequenceNode:
+		found = "list"
+	case yaml.AliasNode:
+		found = "alias"
+	}
+
+	return &Problem{
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf("%s must be %s, found %s", key, expected, strings.TrimPrefix(found, "!!")),
+	}
+}
+
+// syntaxProblem returns the yaml syntax error at its line
+func syntaxProblem(err error) *Problem {
+	problem := &Problem{Line: 1, Column: 1, Message: err.Error()}
+	if match := yamlLine.FindStringSubmatch(err.Error()); match != nil {
+		problem.Line, _ = strconv.Atoi(match[1])
+		problem.Message = match[2]
+	}
+
+	return problem
+}
+
+// suggestion returns a hint with the closest candidate if it is close enough
+func suggestion(value string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := levenshtein(strings.ToLower(value), strings.ToLower(candidate))
+		if bestDistance == -1 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	if bestDistance == -1 || bestDistance > max(2, len(value)/3) {
+		return ""
+	}
+
+	return fmt.Sprintf(" - did you mean %s?", best)
+}
+
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
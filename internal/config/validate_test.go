@@ -0,0 +1,127 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []*struct {
+		data     string
+		expected []*Problem
+	}{
+		{
+			data:     "ls:\n  .js: kebab-case | regex:^[a-z]+$\n",
+			expected: []*Problem{},
+		},
+		{
+			data:     "ls:\n  .js: &kebab kebab-case\n  .ts: *kebab\n  base: &base\n    .dir: *kebab\n    .md: [*kebab]\n  src:\n    <<: *base\n    .go: snake_case\n  lib:\n    <<: [*base]\n",
+			expected: []*Problem{},
+		},
+		{
+			data: "ls:\n  base: &base\n    .js: kebabcas\n  src:\n    <<: *base\n    .js: kebab-case\n  lib: *base\n",
+			expected: []*Problem{
+				{Line: 3, Column: 10, Length: 8, Message: "rule kebabcas not exists - did you mean kebabcase?"},
+			},
+		},
+		{
+			data: "ls:\n  .js: kebab-case | kebabcas\n",
+			expected: []*Problem{
+				{Line: 2, Column: 21, Length: 8, Message: "rule kebabcas not exists - did you mean kebabcase?"},
+			},
+		},
+		{
+			data: "ls:\n  src:\n    .js: \"regex:[a-z | exists:4-1\"\n",
+			expected: []*Problem{
+				{Line: 3, Column: 11, Length: 10, Message: "rule regex failed with invalid regex pattern: error parsing regexp: missing closing ]: `[a-z`"},
+				{Line: 3, Column: 24, Length: 10, Message: "rule exists failed with min is greater than max"},
+			},
+		},
+		{
+			data: "ls:\n  .js: Kebab-Case\n  .ts: foo\n",
+			expected: []*Problem{
+				{Line: 2, Column: 8, Length: 10, Message: "rule Kebab-Case not exists - did you mean kebab-case?"},
+				{Line: 3, Column: 8, Length: 3, Message: "rule foo not exists"},
+			},
+		},
+		{
+			data: "lss:\n  .js: kebab-case\nls:\n  .js:\n    - 1\n  .ts: 1\n  .go:\nignore: dist\nuse_gitignore: yes please\nextends:\n  - a: b\n",
+			expected: []*Problem{
+				{Line: 1, Column: 1, Length: 3, Message: "unknown key lss - did you mean ls?"},
+				{Line: 5, Column: 7, Message: "rule must be a rule string or a rule object, found int"},
+				{Line: 6, Column: 8, Message: ".ts must be a rule string, a list of rules or a mapping, found int"},
+				{Line: 8, Column: 9, Message: "ignore must be a list of strings, found str"},
+				{Line: 9, Column: 16, Message: "use_gitignore must be a boolean, found str"},
+				{Line: 11, Column: 5, Message: "extends must be a string, found mapping"},
+			},
+		},
+		{
+			data: "ls:\n  .js: (kebab-case | camelCase) & lenght:1-30\n  .ts: kebab-case & exists:1\n  .go: (snake_case | kebab-case\n",
+			expected: []*Problem{
+				{Line: 2, Column: 35, Length: 11, Message: "rule lenght not exists - did you mean length?"},
+				{Line: 3, Column: 8, Length: 21, Message: "rules failed with rule exists can't be combined with & or grouped"},
+				{Line: 4, Column: 8, Length: 24, Message: "rules failed with missing )"},
+			},
+		},
+		{
+			data:     "ls:\n  .js:\n    - kebab-case | camelCase\n    - rule: regex\n      pattern: ^[a-z]+ | [0-9]+$\n      message: use lowercase letters or digits\n      severity: warning\n",
+			expected: []*Problem{},
+		},
+		{
+			data: "ls:\n  .js:\n    - kebabcas\n    - rule: regx\n    - rule: regex\n      patern: ^[a-z]+$\n    - rule: kebab-case\n      severity: fatal\n",
+			expected: []*Problem{
+				{Line: 3, Column: 7, Length: 8, Message: "rule kebabcas not exists - did you mean kebabcase?"},
+				{Line: 4, Column: 13, Length: 4, Message: "rule regx not exists - did you mean regex?"},
+				{Line: 5, Column: 7, Message: "rule regex failed with option patern not exists"},
+				{Line: 7, Column: 7, Message: "severity fatal not exists"},
+			},
+		},
+		{
+			data:     "ls:\n  components:\n    .ts:\n      rules: kebab-case\n      message: components are named after their tag\n      url: https://example.com/naming\n    rules:\n      .ts: camelCase\n",
+			expected: []*Problem{},
+		},
+		{
+			data: "ls:\n  .ts:\n    rules:\n      - kebabcas\n    mesage: components are named after their tag\n    url: 1\n",
+			expected: []*Problem{
+				{Line: 4, Column: 9, Length: 8, Message: "rule kebabcas not exists - did you mean kebabcase?"},
+				{Line: 5, Column: 5, Length: 6, Message: "rule set option mesage not exists - did you mean message?"},
+				{Line: 6, Column: 10, Message: "url must be a string, found int"},
+			},
+		},
+		{
+			data:     "exceptions:\n  README.md:\n    rules: [kebab-case]\n    reason: readme files are named by convention\n",
+			expected: []*Problem{},
+		},
+		{
+			data: "exceptions:\n  README.md:\n    rules: [kebab-case]\n  docs/*.md:\n    rules: [exists]\n    reason: docs\n  LICENSE:\n    rules: kebab-case\n    reson: license\n",
+			expected: []*Problem{
+				{Line: 2, Column: 3, Length: 9, Message: "exception README.md failed with exception reason not exists"},
+				{Line: 4, Column: 3, Length: 9, Message: "exception docs/*.md failed with rule exists can't be excepted"},
+				{Line: 8, Column: 12, Message: "rules must be a list of strings, found str"},
+				{Line: 9, Column: 5, Length: 5, Message: "exception option reson not exists - did you mean reason?"},
+			},
+		},
+		{
+			data:     "- ls\n",
+			expected: []*Problem{{Line: 1, Column: 1, Message: "config must be a mapping, found list"}},
+		},
+		{
+			data: "ls:\n  .js: [kebab-case\n",
+			expected: []*Problem{
+				{Line: 1, Column: 1, Message: "did not find expected ',' or ']'"},
+			},
+		},
+	}
+
+	i := 0
+	for _, test := range tests {
+		res := NewConfig(nil, nil).Validate([]byte(test.data))
+
+		if !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, res)
+			return
+		}
+
+		i++
+	}
+}
@@ -0,0 +1,174 @@
+package fix
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const (
+	extSep = "."
+	dir    = ".dir"
+)
+
+type Rename struct {
+	From string
+	To   string
+	Rule string
+}
+
+type Skip struct {
+	Path   string
+	Reason string
+}
+
+// Plan computes the renames for the given errors
+// renames are sorted bottom-up so directory renames don't invalidate the paths of their children
+func Plan(filesystem fs.FS, ruleErrors []*rule.Error) ([]*Rename, []*Skip, error) {
+	renames := make([]*Rename, 0, len(ruleErrors))
+	skips := make([]*Skip, 0)
+	targets := make(map[string][]*Rename)
+
+	for _, ruleErr := range ruleErrors {
+		if ruleErr.IsDir() || ruleErr.GetPath() == "" {
+			continue // exists errors can't be fixed by renaming
+		}
+
+		if isCollision(ruleErr) {
+			continue // unique-casefold errors report the entries of the dir and not the dir name
+		}
+
+		pathDir, basename := path.Split(ruleErr.GetPath())
+		value, ext := basename, ""
+		if ruleErr.GetExt() != dir {
+			if i := strings.Index(basename, extSep); i >= 0 {
+				value, ext = basename[:i], basename[i:]
+			}
+		}
+
+		// the leading dots of hidden entries (e.g. .git) are kept
+		hidden := basename[:len(basename)-len(strings.TrimLeft(basename, extSep))]
+		value = strings.TrimPrefix(value, hidden)
+
+		var target, ruleName string
+		for _, errRule := range ruleErr.GetFailedRules() {
+			suggester, ok := errRule.(rule.Suggester)
+			if !ok {
+				continue
+			}
+
+			if suggestion, ok := suggester.Suggest(value); ok {
+				target, ruleName = hidden+suggestion+ext, errRule.GetName()
+				break
+			}
+		}
+
+		if target == "" || target == basename {
+			skips = append(skips, &Skip{Path: ruleErr.GetPath(), Reason: "no fix available"})
+			continue
+		}
+
+		rename := &Rename{
+			From: ruleErr.GetPath(),
+			To:   pathDir + target,
+			Rule: ruleName,
+		}
+
+		collision, err := collides(filesystem, rename)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if collision != "" {
+			skips = append(skips, &Skip{Path: rename.From, Reason: fmt.Sprintf("%s already exists", collision)})
+			continue
+		}
+
+		key := strings.ToLower(rename.To)
+		targets[key] = append(targets[key], rename)
+		renames = append(renames, rename)
+	}
+
+	// renames with the same target collide with each other
+	renames = slices.DeleteFunc(renames, func(rename *Rename) bool {
+		collisions := targets[strings.ToLower(rename.To)]
+		if len(collisions) == 1 {
+			return false
+		}
+
+		for _, collision := range collisions {
+			if collision != rename {
+				skips = append(skips, &Skip{Path: rename.From, Reason: fmt.Sprintf("%s is also renamed to %s", collision.From, rename.To)})
+				break
+			}
+		}
+
+		return true
+	})
+
+	slices.SortFunc(renames, func(a, b *Rename) int {
+		if depthA, depthB := strings.Count(a.From, "/"), strings.Count(b.From, "/"); depthA != depthB {
+			return depthB - depthA
+		}
+
+		return strings.Compare(a.From, b.From)
+	})
+
+	slices.SortFunc(skips, func(a, b *Skip) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return renames, skips, nil
+}
+
+// isCollision returns true for unique-casefold errors
+func isCollision(ruleErr *rule.Error) bool {
+	for _, errRule := range ruleErr.GetFailedRules() {
+		if _, ok := rule.Unwrap(errRule).(*rule.UniqueCasefold); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// collides returns the sibling which collides with the rename target
+// siblings are compared case-insensitive to prevent collisions on case-insensitive filesystems
+func collides(filesystem fs.FS, rename *Rename) (string, error) {
+	pathDir := path.Dir(rename.From)
+
+	entries, err := fs.ReadDir(filesystem, pathDir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		sibling := path.Join(pathDir, entry.Name())
+		if sibling == rename.From {
+			continue
+		}
+
+		if strings.EqualFold(sibling, rename.To) {
+			return sibling, nil
+		}
+	}
+
+	return "", nil
+}
+
+// Apply renames the planned paths relative to the root directory
+func Apply(root string, renames []*Rename) error {
+	for _, rename := range renames {
+		if err := os.Rename(filepath.Join(root, filepath.FromSlash(rename.From)), filepath.Join(root, filepath.FromSlash(rename.To))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
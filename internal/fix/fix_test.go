@@ -0,0 +1,99 @@
+package fix
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+func TestPlan(t *testing.T) {
+	filesystem := fstest.MapFS{
+		"MyDir":                &fstest.MapFile{Mode: fs.ModeDir},
+		"MyDir/MyFile.test.ts": &fstest.MapFile{Mode: fs.ModePerm},
+		"MyDir/my_other.ts":    &fstest.MapFile{Mode: fs.ModePerm},
+		"MyDir/my-other.ts":    &fstest.MapFile{Mode: fs.ModePerm},
+		"MyDir/Duplicate.ts":   &fstest.MapFile{Mode: fs.ModePerm},
+		"MyDir/duplicate_.ts":  &fstest.MapFile{Mode: fs.ModePerm},
+		"regex.ts":             &fstest.MapFile{Mode: fs.ModePerm},
+		".git":                 &fstest.MapFile{Mode: fs.ModeDir},
+		".My_Hidden":           &fstest.MapFile{Mode: fs.ModeDir},
+		".My_Config.json":      &fstest.MapFile{Mode: fs.ModePerm},
+	}
+
+	exists := new(rule.Exists).Init()
+	regex := new(rule.Regex).Init()
+	if err := regex.SetParameters([]string{"[a-z]+"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ruleErrors := []*rule.Error{
+		{Path: "MyDir", Ext: ".dir", Rules: []rule.Rule{rule.RulesIndex["kebabcase"]}, RWMutex: new(sync.RWMutex)},
+		{Path: "MyDir/MyFile.test.ts", Ext: ".test.ts", Rules: []rule.Rule{regex, rule.RulesIndex["kebabcase"], exists}, RWMutex: new(sync.RWMutex)},
+		{Path: "MyDir/my_other.ts", Ext: ".ts", Rules: []rule.Rule{rule.RulesIndex["kebabcase"]}, RWMutex: new(sync.RWMutex)},
+		{Path: "MyDir/Foo_Bar.ts", Ext: ".ts", Rules: []rule.Rule{rule.RulesIndex["kebabcase"]}, RWMutex: new(sync.RWMutex)},
+		{Path: "MyDir/foo__bar.ts", Ext: ".ts", Rules: []rule.Rule{rule.RulesIndex["kebabcase"]}, RWMutex: new(sync.RWMutex)},
+		{Path: "regex.ts", Ext: ".ts", Rules: []rule.Rule{regex}, RWMutex: new(sync.RWMutex)},
+		{Path: "", Dir: true, Ext: ".ts", Rules: []rule.Rule{exists}, RWMutex: new(sync.RWMutex)},
+		{Path: ".git", Ext: ".dir", Rules: []rule.Rule{rule.RulesIndex["kebabcase"]}, RWMutex: new(sync.RWMutex)},
+		{Path: ".My_Hidden", Ext: ".dir", Rules: []rule.Rule{rule.RulesIndex["kebabcase"]}, RWMutex: new(sync.RWMutex)},
+		{Path: ".My_Config.json", Ext: ".My_Config.json", Rules: []rule.Rule{rule.RulesIndex["kebabcase"]}, RWMutex: new(sync.RWMutex)},
+		{Path: "MyDir", Ext: ".dir", Rules: []rule.Rule{new(rule.UniqueCasefold).Init().(*rule.UniqueCasefold).WithCollisions([]string{"my-other.ts", "My-Other.ts"})}, RWMutex: new(sync.RWMutex)},
+	}
+
+	renames, skips, err := Plan(filesystem, ruleErrors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRenames := []*Rename{
+		{From: "MyDir/MyFile.test.ts", To: "MyDir/my-file.test.ts", Rule: "kebabcase"},
+		{From: ".My_Hidden", To: ".my-hidden", Rule: "kebabcase"},
+		{From: "MyDir", To: "my-dir", Rule: "kebabcase"},
+	}
+
+	if !reflect.DeepEqual(renames, expectedRenames) {
+		t.Errorf("unmatched renames - %+v", renames)
+	}
+
+	expectedSkips := []*Skip{
+		{Path: ".My_Config.json", Reason: "no fix available"},
+		{Path: ".git", Reason: "no fix available"},
+		{Path: "MyDir/Foo_Bar.ts", Reason: "MyDir/foo__bar.ts is also renamed to MyDir/foo-bar.ts"},
+		{Path: "MyDir/foo__bar.ts", Reason: "MyDir/Foo_Bar.ts is also renamed to MyDir/foo-bar.ts"},
+		{Path: "MyDir/my_other.ts", Reason: "MyDir/my-other.ts already exists"},
+		{Path: "regex.ts", Reason: "no fix available"},
+	}
+
+	if !reflect.DeepEqual(skips, expectedSkips) {
+		t.Errorf("unmatched skips - %+v", skips)
+	}
+}
+
+func TestApply(t *testing.T) {
+	root := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(root, "MyDir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "MyDir", "MyFile.ts"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Apply(root, []*Rename{
+		{From: "MyDir/MyFile.ts", To: "MyDir/my-file.ts"},
+		{From: "MyDir", To: "my-dir"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "my-dir", "my-file.ts")); err != nil {
+		t.Errorf("unmatched filesystem - %v", err)
+	}
+}
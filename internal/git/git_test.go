@@ -0,0 +1,206 @@
+package git
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// testRepository writes a repository with loose objects
+type testRepository struct {
+	t    *testing.T
+	root string
+}
+
+func (repo *testRepository) write(name string, content []byte) {
+	path := filepath.Join(repo.root, ".git", filepath.FromSlash(name))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		repo.t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		repo.t.Fatal(err)
+	}
+}
+
+func (repo *testRepository) object(objType string, data []byte) []byte {
+	content := append([]byte(fmt.Sprintf("%s %d\x00", objType, len(data))), data...)
+	hash := sha1.Sum(content)
+	hexHash := hex.EncodeToString(hash[:])
+
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+	if _, err := writer.Write(content); err != nil {
+		repo.t.Fatal(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		repo.t.Fatal(err)
+	}
+
+	repo.write("objects/"+hexHash[:2]+"/"+hexHash[2:], buffer.Bytes())
+	return hash[:]
+}
+
+// tree writes the tree of the paths - paths ending with / are trees
+func (repo *testRepository) tree(entries map[string][]byte) []byte {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var data []byte
+	for _, name := range names {
+		mode, entryName := "100644", name
+		if strings.HasSuffix(name, "/") {
+			mode, entryName = "40000", strings.TrimSuffix(name, "/")
+		}
+
+		data = append(data, mode+" "+entryName+"\x00"...)
+		data = append(data, entries[name]...)
+	}
+
+	return repo.object(objectTree, data)
+}
+
+func (repo *testRepository) commit(tree []byte, parents ...[]byte) []byte {
+	data := fmt.Sprintf("tree %x\n", tree)
+	for _, parent := range parents {
+		data += fmt.Sprintf("parent %x\n", parent)
+	}
+
+	return repo.object(objectCommit, []byte(data+"author a <a@a> 0 +0000\ncommitter a <a@a> 0 +0000\n\nmessage\n"))
+}
+
+// index writes an index file with the given version
+func (repo *testRepository) index(version uint32, paths ...string) {
+	data := append([]byte("DIRC"), make([]byte, 8)...)
+	binary.BigEndian.PutUint32(data[4:], version)
+	binary.BigEndian.PutUint32(data[8:], uint32(len(paths)))
+
+	var previous string
+	for _, path := range paths {
+		start := len(data)
+		data = append(data, make([]byte, indexEntryStat+20)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(path)))
+
+		if version == indexPrefixVersion {
+			common := 0
+			for common < len(previous) && common < len(path) && previous[common] == path[common] {
+				common++
+			}
+
+			data = append(data, byte(len(previous)-common)) // < 128
+			data = append(data, path[common:]...)
+			data = append(data, 0)
+			previous = path
+			continue
+		}
+
+		data = append(data, path...)
+		data = append(data, make([]byte, (len(data)-start+8)&^7-(len(data)-start))...)
+	}
+
+	repo.write("index", data)
+}
+
+func TestRepository(t *testing.T) {
+	repo := &testRepository{t: t, root: t.TempDir()}
+
+	blob := repo.object(objectBlob, []byte("content"))
+	first := repo.commit(repo.tree(map[string][]byte{
+		"README.md": blob,
+		"src/":      repo.tree(map[string][]byte{"Old.ts": blob}),
+	}))
+	second := repo.commit(repo.tree(map[string][]byte{
+		"README.md": blob,
+		"src/":      repo.tree(map[string][]byte{"Old.ts": blob, "added.ts": blob}),
+	}), first)
+
+	tag := repo.object(objectTag, []byte(fmt.Sprintf("object %x\ntype commit\ntag v1\n\nv1\n", first)))
+
+	repo.write("HEAD", []byte("ref: refs/heads/main\n"))
+	repo.write("refs/heads/main", []byte(fmt.Sprintf("%x\n", second)))
+	repo.write("packed-refs", []byte(fmt.Sprintf("# pack-refs with: peeled\n%x refs/tags/v1\n", tag)))
+	if err := os.MkdirAll(filepath.Join(repo.root, "src", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*struct {
+		description string
+		workdir     string
+		version     uint32
+		rev         string
+		expected    []string
+	}{
+		{description: "staged", workdir: "", version: 2, expected: []string{"src/New.ts", "src/sub", "src/sub/file.ts"}},
+		{description: "staged v4", workdir: "", version: 4, expected: []string{"src/New.ts", "src/sub", "src/sub/file.ts"}},
+		{description: "staged subdir", workdir: "src", version: 3, expected: []string{"New.ts", "sub", "sub/file.ts"}},
+		{description: "diff tag", workdir: "", version: 2, rev: "v1", expected: []string{"src/New.ts", "src/added.ts", "src/sub", "src/sub/file.ts"}},
+		{description: "diff parent", workdir: "", version: 2, rev: "main~1", expected: []string{"src/New.ts", "src/added.ts", "src/sub", "src/sub/file.ts"}},
+		{description: "diff abbreviated hash", workdir: "", version: 2, rev: hex.EncodeToString(second)[:7], expected: []string{"src/New.ts", "src/sub", "src/sub/file.ts"}},
+	}
+
+	for i, test := range tests {
+		repo.index(test.version, "README.md", "src/New.ts", "src/added.ts", "src/sub/file.ts")
+
+		r, err := Open(filepath.Join(repo.root, test.workdir))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var paths []string
+		switch test.rev {
+		case "":
+			paths, err = r.Staged()
+		default:
+			paths, err = r.Diff(test.rev)
+		}
+
+		if err != nil {
+			t.Errorf("Test %d (%s) failed with error - %v", i, test.description, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(paths, test.expected) {
+			t.Errorf("Test %d (%s) failed with unmatched return value - %+v", i, test.description, paths)
+		}
+
+		if err = r.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestApplyDelta(t *testing.T) {
+	base := []byte("hello world")
+	delta := []byte{
+		11, 12, // base and result size
+		0x90, 5, // copy offset 0 size 5
+		7, ' ', 'e', 'a', 'r', 't', 'h', '!', // insert
+	}
+
+	result, err := applyDelta(base, delta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(result) != "hello earth!" {
+		t.Errorf("unmatched delta result - %s", result)
+	}
+
+	if _, err = applyDelta([]byte("short"), delta); err == nil {
+		t.Error("expected base size error")
+	}
+}
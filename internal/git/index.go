@@ -0,0 +1,134 @@
+package git
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	indexEntryStat     = 40 // ctime, mtime, dev, ino, mode, uid, gid, size
+	indexFlagExtended  = 0x4000
+	indexFlagNameMask  = 0x0fff
+	indexHeaderSize    = 12
+	indexMinVersion    = 2
+	indexMaxVersion    = 4
+	indexPrefixVersion = 4
+)
+
+var indexMagic = []byte("DIRC")
+
+// readIndex returns the paths of all index entries
+func (repo *Repository) readIndex() ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(repo.gitDir, "index"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIndex(data, repo.hashSize)
+}
+
+// parseIndex parses the entries of the index file versions 2, 3 and 4
+func parseIndex(data []byte, hashSize int) ([]string, error) {
+	if len(data) < indexHeaderSize || !bytes.Equal(data[:4], indexMagic) {
+		return nil, errors.New("invalid index file")
+	}
+
+	version := binary.BigEndian.Uint32(data[4:8])
+	if version < indexMinVersion || version > indexMaxVersion {
+		return nil, fmt.Errorf("unsupported index version %d", version)
+	}
+
+	count := int(binary.BigEndian.Uint32(data[8:12]))
+	paths := make([]string, 0, count)
+
+	pos := indexHeaderSize
+	var previous []byte
+	for i := 0; i < count; i++ {
+		start := pos
+		pos += indexEntryStat + hashSize
+
+		if pos+2 > len(data) {
+			return nil, errors.New("index file is truncated")
+		}
+
+		flags := binary.BigEndian.Uint16(data[pos:])
+		pos += 2
+
+		if version >= 3 && flags&indexFlagExtended != 0 {
+			pos += 2
+		}
+
+		if pos > len(data) {
+			return nil, errors.New("index file is truncated")
+		}
+
+		var name []byte
+		if version == indexPrefixVersion {
+			strip, n := readOffset(data[pos:])
+			if n == 0 || strip > len(previous) {
+				return nil, errors.New("invalid index entry name")
+			}
+			pos += n
+
+			suffix, _, ok := bytes.Cut(data[pos:], []byte{0})
+			if !ok {
+				return nil, errors.New("index file is truncated")
+			}
+			pos += len(suffix) + 1
+
+			name = append(append(make([]byte, 0, len(previous)-strip+len(suffix)), previous[:len(previous)-strip]...), suffix...)
+		} else {
+			var ok bool
+			if name, _, ok = bytes.Cut(data[pos:], []byte{0}); !ok {
+				return nil, errors.New("index file is truncated")
+			}
+
+			if nameLength := int(flags & indexFlagNameMask); nameLength < indexFlagNameMask && nameLength != len(name) {
+				return nil, errors.New("invalid index entry name")
+			}
+
+			// entries are padded with 1-8 nul bytes to a multiple of eight
+			pos = start + (pos-start+len(name)+8)&^7
+		}
+
+		// conflicts have multiple stages of the same path
+		if len(paths) == 0 || paths[len(paths)-1] != string(name) {
+			paths = append(paths, string(name))
+		}
+
+		previous = name
+	}
+
+	return paths, nil
+}
+
+// readOffset reads the variable width integer used by index v4 and ofs deltas
+func readOffset(data []byte) (int, int) {
+	if len(data) == 0 {
+		return 0, 0
+	}
+
+	c := data[0]
+	value := int(c & 0x7f)
+	n := 1
+
+	for c&0x80 != 0 {
+		if n >= len(data) {
+			return 0, 0
+		}
+
+		c = data[n]
+		n++
+		value = ((value + 1) << 7) | int(c&0x7f)
+	}
+
+	return value, n
+}
@@ -0,0 +1,251 @@
+package git
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	objectCommit = "commit"
+	objectTree   = "tree"
+	objectBlob   = "blob"
+	objectTag    = "tag"
+
+	minAbbrev = 4
+	maxPeel   = 10
+)
+
+type commit struct {
+	tree    []byte
+	parents [][]byte
+}
+
+// readObject reads a loose or packed object
+func (repo *Repository) readObject(hash []byte) (string, []byte, error) {
+	objType, data, err := repo.readLooseObject(hash)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return objType, data, err
+	}
+
+	packs, err := repo.getPacks()
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, p := range packs {
+		offset, ok := p.find(hash)
+		if !ok {
+			continue
+		}
+
+		return p.read(repo, offset)
+	}
+
+	return "", nil, fmt.Errorf("object %x not found", hash)
+}
+
+func (repo *Repository) readLooseObject(hash []byte) (string, []byte, error) {
+	hexHash := hex.EncodeToString(hash)
+
+	file, err := os.Open(filepath.Join(repo.commonDir, "objects", hexHash[:2], hexHash[2:]))
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	reader, err := zlib.NewReader(file)
+	if err != nil {
+		return "", nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", nil, err
+	}
+
+	header, data, ok := bytes.Cut(content, []byte{0})
+	if !ok {
+		return "", nil, fmt.Errorf("object %s: invalid header", hexHash)
+	}
+
+	objType, size, ok := strings.Cut(string(header), " ")
+	if !ok {
+		return "", nil, fmt.Errorf("object %s: invalid header", hexHash)
+	}
+
+	if n, err := strconv.Atoi(size); err != nil || n != len(data) {
+		return "", nil, fmt.Errorf("object %s: invalid size", hexHash)
+	}
+
+	return objType, data, nil
+}
+
+// findObject returns the hash of the object with the abbreviated hex hash - nil if no object matches
+func (repo *Repository) findObject(prefix string) ([]byte, error) {
+	if len(prefix) < minAbbrev || len(prefix) > repo.hashSize*2 {
+		return nil, nil
+	}
+
+	if _, err := hex.DecodeString(prefix[:len(prefix)&^1]); err != nil {
+		return nil, nil
+	}
+
+	prefix = strings.ToLower(prefix)
+	matches := make(map[string]struct{})
+
+	entries, err := os.ReadDir(filepath.Join(repo.commonDir, "objects", prefix[:2]))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if name := prefix[:2] + entry.Name(); strings.HasPrefix(name, prefix) {
+			matches[name] = struct{}{}
+		}
+	}
+
+	packs, err := repo.getPacks()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range packs {
+		for _, hash := range p.findPrefix(prefix) {
+			matches[hex.EncodeToString(hash)] = struct{}{}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		for match := range matches {
+			return hex.DecodeString(match)
+		}
+	}
+
+	return nil, fmt.Errorf("short object id %s is ambiguous", prefix)
+}
+
+// peel resolves annotated tags to the tagged commit
+func (repo *Repository) peel(hash []byte) ([]byte, error) {
+	for i := 0; i < maxPeel; i++ {
+		objType, data, err := repo.readObject(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		switch objType {
+		case objectCommit:
+			return hash, nil
+		case objectTag:
+			if hash, err = repo.readHeader(data, "object"); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("object %x is a %s, not a commit", hash, objType)
+		}
+	}
+
+	return nil, fmt.Errorf("object %x is too deeply nested", hash)
+}
+
+func (repo *Repository) readCommit(hash []byte) (*commit, error) {
+	objType, data, err := repo.readObject(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if objType != objectCommit {
+		return nil, fmt.Errorf("object %x is a %s, not a commit", hash, objType)
+	}
+
+	c := new(commit)
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			break // end of headers
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "tree":
+			if c.tree, err = hex.DecodeString(value); err != nil {
+				return nil, err
+			}
+		case "parent":
+			var parent []byte
+			if parent, err = hex.DecodeString(value); err != nil {
+				return nil, err
+			}
+
+			c.parents = append(c.parents, parent)
+		}
+	}
+
+	if len(c.tree) != repo.hashSize {
+		return nil, fmt.Errorf("commit %x has no tree", hash)
+	}
+
+	return c, nil
+}
+
+// readHeader returns the hash of the header key of a commit or tag object
+func (repo *Repository) readHeader(data []byte, key string) ([]byte, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			break
+		}
+
+		if value, ok := strings.CutPrefix(line, key+" "); ok {
+			return hex.DecodeString(value)
+		}
+	}
+
+	return nil, fmt.Errorf("object header %s not found", key)
+}
+
+// walkTree adds all paths of the tree recursively to the paths
+func (repo *Repository) walkTree(hash []byte, prefix string, paths map[string]struct{}) error {
+	objType, data, err := repo.readObject(hash)
+	if err != nil {
+		return err
+	}
+
+	if objType != objectTree {
+		return fmt.Errorf("object %x is a %s, not a tree", hash, objType)
+	}
+
+	for len(data) > 0 {
+		header, rest, ok := bytes.Cut(data, []byte{0})
+		if !ok || len(rest) < repo.hashSize {
+			return fmt.Errorf("tree %x is invalid", hash)
+		}
+
+		mode, name, ok := bytes.Cut(header, []byte(" "))
+		if !ok {
+			return fmt.Errorf("tree %x is invalid", hash)
+		}
+
+		entryPath := prefix + string(name)
+		paths[entryPath] = struct{}{}
+
+		if string(mode) == "40000" {
+			if err = repo.walkTree(rest[:repo.hashSize], entryPath+"/", paths); err != nil {
+				return err
+			}
+		}
+
+		data = rest[repo.hashSize:]
+	}
+
+	return nil
+}
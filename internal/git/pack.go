@@ -0,0 +1,406 @@
+package git
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	packObjectCommit   = 1
+	packObjectTree     = 2
+	packObjectBlob     = 3
+	packObjectTag      = 4
+	packObjectOfsDelta = 6
+	packObjectRefDelta = 7
+
+	maxDeltaDepth = 4096
+)
+
+var packIdxMagic = []byte{0xff, 't', 'O', 'c'}
+
+var packObjectTypes = map[int]string{
+	packObjectCommit: objectCommit,
+	packObjectTree:   objectTree,
+	packObjectBlob:   objectBlob,
+	packObjectTag:    objectTag,
+}
+
+type pack struct {
+	path     string
+	hashSize int
+	fanout   [256]uint32
+	hashes   []byte
+	offsets  []byte
+	large    []byte
+	file     *os.File
+	cache    map[int64]*packObject
+	*sync.Mutex
+}
+
+type packObject struct {
+	objType string
+	data    []byte
+}
+
+// getPacks loads the index of all pack files
+func (repo *Repository) getPacks() ([]*pack, error) {
+	repo.packsOnce.Do(func() {
+		var matches []string
+		if matches, repo.packsErr = filepath.Glob(filepath.Join(repo.commonDir, "objects", "pack", "*.idx")); repo.packsErr != nil {
+			return
+		}
+
+		for _, match := range matches {
+			var p *pack
+			if p, repo.packsErr = openPack(match, repo.hashSize); repo.packsErr != nil {
+				return
+			}
+
+			repo.packs = append(repo.packs, p)
+		}
+	})
+
+	return repo.packs, repo.packsErr
+}
+
+// openPack reads a version 2 pack index
+func openPack(idxPath string, hashSize int) (*pack, error) {
+	idx, err := os.ReadFile(idxPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(idx) < 8+256*4 || !bytes.Equal(idx[:4], packIdxMagic) || binary.BigEndian.Uint32(idx[4:8]) != 2 {
+		return nil, fmt.Errorf("%s: unsupported pack index", idxPath)
+	}
+
+	p := &pack{
+		path:     strings.TrimSuffix(idxPath, ".idx") + ".pack",
+		hashSize: hashSize,
+		cache:    make(map[int64]*packObject),
+		Mutex:    new(sync.Mutex),
+	}
+
+	for i := 0; i < 256; i++ {
+		p.fanout[i] = binary.BigEndian.Uint32(idx[8+i*4:])
+	}
+
+	count := int(p.fanout[255])
+	pos := 8 + 256*4
+
+	if len(idx) < pos+count*(hashSize+4+4) {
+		return nil, fmt.Errorf("%s: pack index is truncated", idxPath)
+	}
+
+	p.hashes = idx[pos : pos+count*hashSize]
+	pos += count * hashSize
+	pos += count * 4 // crc32
+	p.offsets = idx[pos : pos+count*4]
+	pos += count * 4
+	p.large = idx[pos:]
+
+	return p, nil
+}
+
+func (p *pack) close() error {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.file == nil {
+		return nil
+	}
+
+	err := p.file.Close()
+	p.file = nil
+	return err
+}
+
+func (p *pack) count() int {
+	return int(p.fanout[255])
+}
+
+func (p *pack) hash(i int) []byte {
+	return p.hashes[i*p.hashSize : (i+1)*p.hashSize]
+}
+
+// find returns the pack offset of the object
+func (p *pack) find(hash []byte) (int64, bool) {
+	lo, hi := 0, int(p.fanout[hash[0]])
+	if hash[0] > 0 {
+		lo = int(p.fanout[hash[0]-1])
+	}
+
+	i := lo + sort.Search(hi-lo, func(i int) bool {
+		return bytes.Compare(p.hash(lo+i), hash) >= 0
+	})
+
+	if i >= hi || !bytes.Equal(p.hash(i), hash) {
+		return 0, false
+	}
+
+	offset := int64(binary.BigEndian.Uint32(p.offsets[i*4:]))
+	if offset&0x80000000 != 0 { // large offset
+		pos := int(offset&0x7fffffff) * 8
+		if pos+8 > len(p.large) {
+			return 0, false
+		}
+
+		offset = int64(binary.BigEndian.Uint64(p.large[pos:]))
+	}
+
+	return offset, true
+}
+
+// findPrefix returns all hashes which start with the hex prefix
+func (p *pack) findPrefix(prefix string) [][]byte {
+	matches := make([][]byte, 0)
+
+	for i := 0; i < p.count(); i++ {
+		if strings.HasPrefix(hex.EncodeToString(p.hash(i)), prefix) {
+			matches = append(matches, p.hash(i))
+		}
+	}
+
+	return matches
+}
+
+// read reads and resolves the object at the offset
+func (p *pack) read(repo *Repository, offset int64) (string, []byte, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.file == nil {
+		file, err := os.Open(p.path)
+		if err != nil {
+			return "", nil, err
+		}
+
+		p.file = file
+	}
+
+	obj, err := p.readAt(repo, offset, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return obj.objType, obj.data, nil
+}
+
+func (p *pack) readAt(repo *Repository, offset int64, depth int) (*packObject, error) {
+	if obj, ok := p.cache[offset]; ok {
+		return obj, nil
+	}
+
+	if depth > maxDeltaDepth {
+		return nil, fmt.Errorf("%s: delta chain is too long", p.path)
+	}
+
+	header := make([]byte, 16+p.hashSize)
+	n, err := p.file.ReadAt(header, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	header = header[:n]
+
+	pos := 0
+	next := func() (byte, error) {
+		if pos >= len(header) {
+			return 0, fmt.Errorf("%s: invalid object header at %d", p.path, offset)
+		}
+
+		pos++
+		return header[pos-1], nil
+	}
+
+	c, err := next()
+	if err != nil {
+		return nil, err
+	}
+
+	objType := int(c>>4) & 7
+	size := uint64(c & 0x0f)
+	for shift := 4; c&0x80 != 0; shift += 7 {
+		if c, err = next(); err != nil {
+			return nil, err
+		}
+
+		size |= uint64(c&0x7f) << shift
+	}
+
+	var base *packObject
+	switch objType {
+	case packObjectOfsDelta:
+		baseOffset, n := readOffset(header[pos:])
+		if n == 0 {
+			return nil, fmt.Errorf("%s: invalid delta base at %d", p.path, offset)
+		}
+		pos += n
+
+		if baseOffset <= 0 || int64(baseOffset) > offset {
+			return nil, fmt.Errorf("%s: invalid delta base at %d", p.path, offset)
+		}
+
+		if base, err = p.readAt(repo, offset-int64(baseOffset), depth+1); err != nil {
+			return nil, err
+		}
+	case packObjectRefDelta:
+		if pos+p.hashSize > len(header) {
+			return nil, fmt.Errorf("%s: invalid delta base at %d", p.path, offset)
+		}
+
+		baseHash := header[pos : pos+p.hashSize]
+		pos += p.hashSize
+
+		if baseOffset, ok := p.find(baseHash); ok {
+			if base, err = p.readAt(repo, baseOffset, depth+1); err != nil {
+				return nil, err
+			}
+		} else {
+			// base object in another pack or loose
+			baseType, baseData, err := repo.readObject(baseHash)
+			if err != nil {
+				return nil, err
+			}
+
+			base = &packObject{objType: baseType, data: baseData}
+		}
+	default:
+		if _, ok := packObjectTypes[objType]; !ok {
+			return nil, fmt.Errorf("%s: invalid object type %d at %d", p.path, objType, offset)
+		}
+	}
+
+	if size > math.MaxInt32 {
+		return nil, fmt.Errorf("%s: object at %d is too large", p.path, offset)
+	}
+
+	reader, err := zlib.NewReader(io.NewSectionReader(p.file, offset+int64(pos), math.MaxInt64-offset-int64(pos)))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data := make([]byte, size)
+	if _, err = io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+
+	obj := &packObject{objType: packObjectTypes[objType], data: data}
+	if base != nil {
+		if data, err = applyDelta(base.data, data); err != nil {
+			return nil, fmt.Errorf("%s: %s at %d", p.path, err.Error(), offset)
+		}
+
+		obj = &packObject{objType: base.objType, data: data}
+	}
+
+	// blobs are never needed again to resolve commits and trees
+	if obj.objType != objectBlob {
+		p.cache[offset] = obj
+	}
+
+	return obj, nil
+}
+
+// applyDelta applies the git delta instructions to the base
+func applyDelta(base []byte, delta []byte) ([]byte, error) {
+	pos := 0
+	readSize := func() (int, error) {
+		size := 0
+		for shift := 0; ; shift += 7 {
+			if pos >= len(delta) {
+				return 0, errors.New("invalid delta header")
+			}
+
+			c := delta[pos]
+			pos++
+			size |= int(c&0x7f) << shift
+
+			if c&0x80 == 0 {
+				return size, nil
+			}
+		}
+	}
+
+	baseSize, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+
+	if baseSize != len(base) {
+		return nil, errors.New("invalid delta base size")
+	}
+
+	resultSize, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]byte, 0, resultSize)
+	for pos < len(delta) {
+		c := delta[pos]
+		pos++
+
+		switch {
+		case c&0x80 != 0: // copy from base
+			var copyOffset, copySize int
+			for i := 0; i < 4; i++ {
+				if c&(1<<i) != 0 {
+					if pos >= len(delta) {
+						return nil, errors.New("invalid delta copy")
+					}
+
+					copyOffset |= int(delta[pos]) << (8 * i)
+					pos++
+				}
+			}
+
+			for i := 0; i < 3; i++ {
+				if c&(1<<(4+i)) != 0 {
+					if pos >= len(delta) {
+						return nil, errors.New("invalid delta copy")
+					}
+
+					copySize |= int(delta[pos]) << (8 * i)
+					pos++
+				}
+			}
+
+			if copySize == 0 {
+				copySize = 0x10000
+			}
+
+			if copyOffset+copySize > len(base) {
+				return nil, errors.New("invalid delta copy")
+			}
+
+			result = append(result, base[copyOffset:copyOffset+copySize]...)
+		case c != 0: // insert
+			if pos+int(c) > len(delta) {
+				return nil, errors.New("invalid delta insert")
+			}
+
+			result = append(result, delta[pos:pos+int(c)]...)
+			pos += int(c)
+		default:
+			return nil, errors.New("invalid delta instruction")
+		}
+	}
+
+	if len(result) != resultSize {
+		return nil, errors.New("invalid delta result size")
+	}
+
+	return result, nil
+}
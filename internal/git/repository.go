@@ -0,0 +1,452 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	gitDirName  = ".git"
+	gitDirFile  = "gitdir: "
+	symbolicRef = "ref: "
+	maxRefDepth = 10
+)
+
+var ErrNotRepository = errors.New("not a git repository")
+
+// revSuffix matches ancestry suffixes like ~2, ^ or ^2
+var revSuffix = regexp.MustCompile(`[~^][0-9]*$`)
+
+type Repository struct {
+	root      string // worktree root
+	gitDir    string // per worktree git dir
+	commonDir string // shared git dir (objects, refs)
+	prefix    string // workdir relative to the worktree root - empty if equal
+	hashSize  int
+	packs     []*pack
+	packsOnce sync.Once
+	packsErr  error
+}
+
+// Open opens the git repository which contains the workdir
+// the .git directory is searched in the workdir and all parent directories
+func Open(workdir string) (*Repository, error) {
+	var err error
+	var dir string
+
+	if dir, err = filepath.Abs(workdir); err != nil {
+		return nil, err
+	}
+
+	if dir, err = filepath.EvalSymlinks(dir); err != nil {
+		return nil, err
+	}
+
+	for current := dir; ; current = filepath.Dir(current) {
+		var gitDir string
+		if gitDir, err = findGitDir(current); err != nil {
+			return nil, err
+		}
+
+		if gitDir != "" {
+			return newRepository(current, gitDir, dir)
+		}
+
+		if filepath.Dir(current) == current {
+			return nil, ErrNotRepository
+		}
+	}
+}
+
+// findGitDir returns the git dir of the directory - empty if the directory contains no .git
+func findGitDir(dir string) (string, error) {
+	gitPath := filepath.Join(dir, gitDirName)
+
+	info, err := os.Stat(gitPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if info.IsDir() {
+		return gitPath, nil
+	}
+
+	// worktrees and submodules
+	content, err := os.ReadFile(gitPath)
+	if err != nil {
+		return "", err
+	}
+
+	line := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(line, gitDirFile) {
+		return "", fmt.Errorf("%s: invalid gitdir file", gitPath)
+	}
+
+	gitDir := filepath.FromSlash(strings.TrimPrefix(line, gitDirFile))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+
+	return gitDir, nil
+}
+
+func newRepository(root string, gitDir string, workdir string) (*Repository, error) {
+	repo := &Repository{
+		root:      root,
+		gitDir:    gitDir,
+		commonDir: gitDir,
+		hashSize:  20,
+	}
+
+	prefix, err := filepath.Rel(root, workdir)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix = filepath.ToSlash(prefix); prefix != "." {
+		repo.prefix = prefix
+	}
+
+	// linked worktrees share objects and refs with the main repository
+	if commonDir, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		repo.commonDir = filepath.FromSlash(strings.TrimSpace(string(commonDir)))
+		if !filepath.IsAbs(repo.commonDir) {
+			repo.commonDir = filepath.Join(gitDir, repo.commonDir)
+		}
+	}
+
+	if err = repo.readConfig(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// readConfig reads the object format of the repository
+func (repo *Repository) readConfig() error {
+	file, err := os.Open(filepath.Join(repo.commonDir, "config"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "objectformat") {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "sha1":
+			repo.hashSize = 20
+		case "sha256":
+			repo.hashSize = 32
+		default:
+			return fmt.Errorf("unsupported object format %s", strings.TrimSpace(value))
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Close closes all opened pack files
+func (repo *Repository) Close() error {
+	var errs []error
+
+	for _, p := range repo.packs {
+		errs = append(errs, p.close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Staged returns the paths which are added to the index compared to HEAD
+func (repo *Repository) Staged() ([]string, error) {
+	tree, err := repo.resolveTree("HEAD")
+	if errors.Is(err, errUnbornHead) {
+		return repo.added(nil)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.added(tree)
+}
+
+// Diff returns the paths which are added to the index compared to the revision
+func (repo *Repository) Diff(rev string) ([]string, error) {
+	tree, err := repo.resolveTree(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.added(tree)
+}
+
+// added returns all index paths and their parent directories which are not part of the tree
+// paths are relative to the workdir - paths outside the workdir are skipped
+func (repo *Repository) added(tree []byte) ([]string, error) {
+	base := make(map[string]struct{})
+	if tree != nil {
+		if err := repo.walkTree(tree, "", base); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := repo.readIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	added := make(map[string]struct{})
+	for _, entry := range entries {
+		for p := entry; p != "." && p != ""; p = path.Dir(p) {
+			if _, ok := base[p]; ok {
+				break
+			}
+
+			added[p] = struct{}{}
+		}
+	}
+
+	paths := make([]string, 0, len(added))
+	for p := range added {
+		if repo.prefix == "" {
+			paths = append(paths, p)
+			continue
+		}
+
+		if rel, ok := strings.CutPrefix(p, repo.prefix+"/"); ok {
+			paths = append(paths, rel)
+		}
+	}
+
+	slices.Sort(paths)
+	return paths, nil
+}
+
+var errUnbornHead = errors.New("HEAD has no commits")
+
+// resolveTree resolves the revision to a tree hash
+func (repo *Repository) resolveTree(rev string) ([]byte, error) {
+	hash, err := repo.resolveCommit(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.readCommit(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return commit.tree, nil
+}
+
+// resolveCommit resolves revisions like HEAD, main, origin/main, v1.0.0^, HEAD~2 or (abbreviated) hashes
+func (repo *Repository) resolveCommit(rev string) ([]byte, error) {
+	var suffixes []string
+	base := rev
+	for {
+		loc := revSuffix.FindStringIndex(base)
+		if loc == nil || loc[0] == 0 {
+			break
+		}
+
+		suffixes = append([]string{base[loc[0]:]}, suffixes...)
+		base = base[:loc[0]]
+	}
+
+	hash, err := repo.resolveRef(base)
+	if err != nil {
+		return nil, err
+	}
+
+	if hash, err = repo.peel(hash); err != nil {
+		return nil, err
+	}
+
+	for _, suffix := range suffixes {
+		n := 1
+		if len(suffix) > 1 {
+			if n, err = strconv.Atoi(suffix[1:]); err != nil {
+				return nil, fmt.Errorf("invalid revision %s", rev)
+			}
+		}
+
+		switch suffix[0] {
+		case '~':
+			for i := 0; i < n; i++ {
+				if hash, err = repo.parent(hash, 1, rev); err != nil {
+					return nil, err
+				}
+			}
+		case '^':
+			if n == 0 {
+				continue
+			}
+
+			if hash, err = repo.parent(hash, n, rev); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return hash, nil
+}
+
+func (repo *Repository) parent(hash []byte, n int, rev string) ([]byte, error) {
+	commit, err := repo.readCommit(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if n > len(commit.parents) {
+		return nil, fmt.Errorf("revision %s not found", rev)
+	}
+
+	return commit.parents[n-1], nil
+}
+
+// resolveRef resolves a ref name or (abbreviated) hash to a hash
+func (repo *Repository) resolveRef(name string) ([]byte, error) {
+	if name == "" {
+		return nil, fmt.Errorf("empty revision")
+	}
+
+	if name == "@" {
+		name = "HEAD"
+	}
+
+	if hash, err := hex.DecodeString(name); err == nil && len(hash) == repo.hashSize {
+		return hash, nil
+	}
+
+	candidates := []string{
+		name,
+		"refs/" + name,
+		"refs/tags/" + name,
+		"refs/heads/" + name,
+		"refs/remotes/" + name,
+		"refs/remotes/" + name + "/HEAD",
+	}
+
+	for _, candidate := range candidates {
+		hash, err := repo.readRef(candidate, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if hash != nil {
+			return hash, nil
+		}
+	}
+
+	hash, err := repo.findObject(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if hash == nil {
+		return nil, fmt.Errorf("revision %s not found", name)
+	}
+
+	return hash, nil
+}
+
+// readRef returns the hash of the ref - nil if the ref not exists
+func (repo *Repository) readRef(name string, depth int) ([]byte, error) {
+	if depth > maxRefDepth {
+		return nil, fmt.Errorf("ref %s is too deeply nested", name)
+	}
+
+	dir := repo.commonDir
+	if !strings.HasPrefix(name, "refs/") {
+		dir = repo.gitDir // HEAD, ORIG_HEAD, FETCH_HEAD, ...
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	switch {
+	case err == nil:
+		line, _, _ := strings.Cut(string(content), "\n")
+		if target, ok := strings.CutPrefix(line, symbolicRef); ok {
+			hash, err := repo.readRef(strings.TrimSpace(target), depth+1)
+			if err == nil && hash == nil && name == "HEAD" {
+				return nil, errUnbornHead
+			}
+
+			return hash, err
+		}
+
+		if len(line) < repo.hashSize*2 {
+			return nil, fmt.Errorf("invalid ref %s", name)
+		}
+
+		return hex.DecodeString(line[:repo.hashSize*2])
+	case errors.Is(err, os.ErrNotExist), isDirError(err):
+		if !strings.HasPrefix(name, "refs/") {
+			return nil, nil
+		}
+
+		return repo.readPackedRef(name)
+	default:
+		return nil, err
+	}
+}
+
+func isDirError(err error) bool {
+	var pathErr *os.PathError
+	if !errors.As(err, &pathErr) {
+		return false
+	}
+
+	info, statErr := os.Stat(pathErr.Path)
+	return statErr == nil && info.IsDir()
+}
+
+// readPackedRef returns the hash of the ref from the packed-refs file - nil if the ref not exists
+func (repo *Repository) readPackedRef(name string) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Join(repo.commonDir, "packed-refs"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		if len(line) == 0 || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+
+		hash, ref, ok := bytes.Cut(line, []byte(" "))
+		if !ok || string(bytes.TrimSpace(ref)) != name {
+			continue
+		}
+
+		return hex.DecodeString(string(hash))
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,240 @@
+package gitignore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	File    = ".gitignore"
+	sep     = "/"
+	negate  = "!"
+	comment = "#"
+)
+
+type pattern struct {
+	regex   *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Matcher matches paths against all loaded .gitignore files
+// patterns of deeper .gitignore files take precedence over patterns of their parent directories
+type Matcher struct {
+	patterns map[string][]*pattern
+	*sync.RWMutex
+}
+
+func NewMatcher() *Matcher {
+	return &Matcher{
+		patterns: make(map[string][]*pattern),
+		RWMutex:  new(sync.RWMutex),
+	}
+}
+
+// Load loads the .gitignore file of the directory if exists
+func (matcher *Matcher) Load(filesystem fs.FS, dir string) error {
+	content, err := fs.ReadFile(filesystem, path.Join(dir, File))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	patterns, err := parse(content)
+	if err != nil {
+		return err
+	}
+
+	matcher.Lock()
+	defer matcher.Unlock()
+
+	matcher.patterns[normalize(dir)] = patterns
+	return nil
+}
+
+// Match reports whether the path is ignored by the loaded .gitignore files
+func (matcher *Matcher) Match(p string, isDir bool) bool {
+	p = normalize(p)
+	if p == "" {
+		return false
+	}
+
+	matcher.RLock()
+	defer matcher.RUnlock()
+
+	var ignored bool
+	dirs := strings.Split(p, sep)
+	for i := 0; i < len(dirs); i++ {
+		patterns, ok := matcher.patterns[strings.Join(dirs[:i], sep)]
+		if !ok {
+			continue
+		}
+
+		rel := strings.Join(dirs[i:], sep)
+		for _, pattern := range patterns {
+			if pattern.dirOnly && !isDir {
+				continue
+			}
+
+			if pattern.regex.MatchString(rel) {
+				ignored = !pattern.negate
+			}
+		}
+	}
+
+	return ignored
+}
+
+func normalize(dir string) string {
+	if dir == "." {
+		return ""
+	}
+
+	return strings.Trim(dir, sep)
+}
+
+// parse parses the patterns of a .gitignore file
+func parse(content []byte) ([]*pattern, error) {
+	patterns := make([]*pattern, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		if line == "" || strings.HasPrefix(line, comment) {
+			continue
+		}
+
+		line = trimTrailingSpaces(line)
+		if line == "" {
+			continue
+		}
+
+		p := new(pattern)
+		if strings.HasPrefix(line, negate) {
+			p.negate = true
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, sep) {
+			p.dirOnly = true
+			line = strings.TrimRight(line, sep)
+		}
+
+		if line == "" {
+			continue
+		}
+
+		// patterns with a separator at the beginning or middle are relative to the .gitignore directory
+		anchored := strings.Contains(line, sep)
+		line = strings.TrimPrefix(line, sep)
+
+		expr := translate(line)
+		if anchored {
+			expr = "^" + expr + "$"
+		} else {
+			expr = "^(?:.*/)?" + expr + "$"
+		}
+
+		var err error
+		if p.regex, err = regexp.Compile(expr); err != nil {
+			continue // invalid patterns are ignored by git as well
+		}
+
+		patterns = append(patterns, p)
+	}
+
+	return patterns, scanner.Err()
+}
+
+// trimTrailingSpaces removes trailing spaces unless they are escaped with a backslash
+func trimTrailingSpaces(line string) string {
+	end := len(line)
+	for end > 0 && line[end-1] == ' ' {
+		if end >= 2 && line[end-2] == '\\' {
+			break
+		}
+
+		end--
+	}
+
+	return line[:end]
+}
+
+// translate translates the gitignore pattern to a regular expression
+func translate(pattern string) string {
+	var expr strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				leading := i == 0 || pattern[i-1] == '/'
+				trailing := i+2 == len(pattern) || pattern[i+2] == '/'
+
+				if leading && trailing {
+					switch {
+					case i+2 == len(pattern): // a/** or **
+						expr.WriteString(".*")
+					default: // **/a or a/**/b
+						expr.WriteString("(?:.*/)?")
+						i++ // skip the separator
+					}
+
+					i++
+					continue
+				}
+
+				// other consecutive asterisks are regular asterisks
+				for i+1 < len(pattern) && pattern[i+1] == '*' {
+					i++
+				}
+			}
+
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end == 0 && i+2 < len(pattern) { // []] includes the closing bracket
+				if next := strings.IndexByte(pattern[i+2:], ']'); next >= 0 {
+					end = next + 1
+				}
+			}
+
+			if end < 0 {
+				expr.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") || strings.HasPrefix(class, "^") {
+				class = "^" + class[1:]
+			}
+
+			expr.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+
+			expr.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return expr.String()
+}
@@ -0,0 +1,78 @@
+package gitignore
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMatcher(t *testing.T) {
+	filesystem := fstest.MapFS{
+		".gitignore": &fstest.MapFile{Data: []byte(`# comment
+node_modules
+/dist
+build/
+*.log
+!important.log
+docs/**/*.tmp
+\#hash
+trailing\ 
+**/cache
+a/**
+`)},
+		"src/.gitignore":     &fstest.MapFile{Data: []byte("generated/\n!debug.log\n/local.ts\n")},
+		"src/sub/.gitignore": &fstest.MapFile{Data: []byte("*.ts\n!keep.ts\n")},
+	}
+
+	matcher := NewMatcher()
+	for _, dir := range []string{".", "src", "src/sub", "other"} {
+		if err := matcher.Load(filesystem, dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []*struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{path: ".", isDir: true, expected: false},
+		{path: "node_modules", isDir: true, expected: true},
+		{path: "src/node_modules", isDir: true, expected: true},
+		{path: "dist", isDir: true, expected: true},
+		{path: "src/dist", isDir: true, expected: false},
+		{path: "build", isDir: true, expected: true},
+		{path: "build", isDir: false, expected: false},
+		{path: "src/build", isDir: true, expected: true},
+		{path: "error.log", isDir: false, expected: true},
+		{path: "src/error.log", isDir: false, expected: true},
+		{path: "important.log", isDir: false, expected: false},
+		{path: "src/debug.log", isDir: false, expected: false},
+		{path: "docs/a/b/file.tmp", isDir: false, expected: true},
+		{path: "docs/file.tmp", isDir: false, expected: true},
+		{path: "file.tmp", isDir: false, expected: false},
+		{path: "#hash", isDir: false, expected: true},
+		{path: "trailing ", isDir: false, expected: true},
+		{path: "x/y/cache", isDir: true, expected: true},
+		{path: "a", isDir: true, expected: false},
+		{path: "a/b", isDir: false, expected: true},
+		{path: "src/generated", isDir: true, expected: true},
+		{path: "src/local.ts", isDir: false, expected: true},
+		{path: "src/a/local.ts", isDir: false, expected: false},
+		{path: "src/sub/file.ts", isDir: false, expected: true},
+		{path: "src/sub/keep.ts", isDir: false, expected: false},
+		{path: "src/file.ts", isDir: false, expected: false},
+	}
+
+	for i, test := range tests {
+		if res := matcher.Match(test.path, test.isDir); res != test.expected {
+			t.Errorf("Test %d (%s) failed with unmatched return value - %+v", i, test.path, res)
+		}
+	}
+}
+
+func TestMatcher_LoadMissing(t *testing.T) {
+	if err := NewMatcher().Load(fstest.MapFS{"a": &fstest.MapFile{Mode: fs.ModeDir}}, "a"); err != nil {
+		t.Errorf("unmatched error - %v", err)
+	}
+}
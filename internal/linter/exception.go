@@ -0,0 +1,117 @@
+package linter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/loeffel-io/ls-lint/v2/internal/config"
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+// exception exempts the paths matching its pattern from the rules
+type exception struct {
+	pattern string
+	rules   map[string]bool
+	used    bool
+	*sync.RWMutex
+}
+
+// newExceptions returns the exceptions with the rule names of the config
+// patterns are prefixed with the dir of nested configs
+func newExceptions(lslintConfig *config.Config, configExceptions map[string]*config.Exception, prefix string) ([]*exception, error) {
+	exceptions := make([]*exception, 0, len(configExceptions))
+	for _, pattern := range slices.Sorted(maps.Keys(configExceptions)) {
+		names, err := lslintConfig.GetExceptionRules(configExceptions[pattern])
+		if err != nil {
+			return nil, fmt.Errorf("exception %s failed with %s", pattern, err.Error())
+		}
+
+		if prefix != "" {
+			pattern = fmt.Sprintf("%s/%s", prefix, pattern)
+		}
+
+		if !doublestar.ValidatePattern(pattern) {
+			return nil, fmt.Errorf("exception %s failed with invalid glob", pattern)
+		}
+
+		exceptions = append(exceptions, &exception{
+			pattern: pattern,
+			rules:   names,
+			RWMutex: new(sync.RWMutex),
+		})
+	}
+
+	return exceptions, nil
+}
+
+func (exception *exception) match(path string) bool {
+	match, _ := doublestar.Match(exception.pattern, path)
+	return match
+}
+
+func (exception *exception) use() {
+	exception.Lock()
+	defer exception.Unlock()
+
+	exception.used = true
+}
+
+func (exception *exception) isUsed() bool {
+	exception.RLock()
+	defer exception.RUnlock()
+
+	return exception.used
+}
+
+// except removes the rules of the exceptions which match the path
+// exclusive rules are kept - exempted paths are still counted by exists rules
+// exceptions are used if they exempt the path from a failing rule
+func except(exceptions []*exception, path string, rules []rule.Rule, valueOf rule.ValueFunc, pathDir string) ([]rule.Rule, error) {
+	for _, e := range exceptions {
+		if !e.match(path) {
+			continue
+		}
+
+		var valid bool
+		kept := make([]rule.Rule, 0, len(rules))
+		for _, r := range rules {
+			if r.GetExclusive() {
+				kept = append(kept, r)
+				continue
+			}
+
+			exceptedRule, excepted := rule.Except(r, e.rules)
+			for _, removed := range excepted {
+				ok, err := rule.Evaluate(removed, valueOf, pathDir, true)
+				if err != nil {
+					return nil, err
+				}
+
+				if !ok {
+					e.use()
+				}
+			}
+
+			if exceptedRule == nil {
+				valid = true
+				continue
+			}
+
+			kept = append(kept, exceptedRule)
+		}
+
+		// rules are alternatives - the path is valid if one of them is exempted
+		if valid {
+			kept = slices.DeleteFunc(kept, func(r rule.Rule) bool {
+				return !r.GetExclusive()
+			})
+		}
+
+		rules = kept
+	}
+
+	return rules, nil
+}
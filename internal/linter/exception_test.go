@@ -0,0 +1,72 @@
+package linter
+
+import (
+	"io/fs"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/config"
+	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+func TestLinter_Exceptions(t *testing.T) {
+	data := []byte(`ls:
+  .md: kebab-case
+  docs:
+    .md:
+      - exists:2
+      - kebab-case & length:1-10
+exceptions:
+  README.md:
+    rules: [kebab-case]
+    reason: readme files are named by convention
+  changelog.md:
+    rules: [kebab-case]
+    reason: changelogs are named by convention
+  LICENSE.md:
+    rules: [kebabcase]
+    reason: license files are named by convention
+  docs/**/*.md:
+    rules: [kebab-case]
+    reason: docs keep their titles
+`)
+
+	lslintConfig, err := config.Parse(nil, ".ls-lint.yml", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filesystem := fstest.MapFS{
+		"README.md":                 &fstest.MapFile{Mode: fs.ModePerm},
+		"NotKebab.md":               &fstest.MapFile{Mode: fs.ModePerm},
+		"changelog.md":              &fstest.MapFile{Mode: fs.ModePerm},
+		"docs/Guide.md":             &fstest.MapFile{Mode: fs.ModePerm},
+		"docs/VeryLongGuideName.md": &fstest.MapFile{Mode: fs.ModePerm},
+	}
+
+	linter := NewLinter(".", lslintConfig, debug.NewStatistic(), make([]*rule.Error, 0))
+	if err = linter.Run(filesystem, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// the exempted docs are still counted by exists
+	expected := []string{"NotKebab.md:expected not-kebab.md (kebab-case)", "docs/VeryLongGuideName.md:length:1-10"}
+	actual := make([]string, 0, len(linter.GetErrors()))
+	for _, ruleErr := range linter.GetErrors() {
+		for _, errRule := range ruleErr.GetFailedRules() {
+			actual = append(actual, ruleErr.GetPath()+":"+errRule.GetErrorMessage())
+		}
+	}
+
+	slices.Sort(actual)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Test failed with unmatched errors - %+v", actual)
+	}
+
+	if unused := linter.GetUnusedExceptions(); !reflect.DeepEqual(unused, []string{"LICENSE.md", "changelog.md"}) {
+		t.Errorf("Test failed with unmatched unused exceptions - %+v", unused)
+	}
+}
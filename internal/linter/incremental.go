@@ -0,0 +1,439 @@
+package linter
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/loeffel-io/ls-lint/v2/internal/config"
+	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/gitignore"
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+// Incremental keeps the glob expanded index and the errors of a linted filesystem in memory
+// and re-validates only changed paths and the exists rules of the affected directories
+type Incremental struct {
+	linter     *Linter
+	filesystem fs.FS
+	state      *state
+	static     map[string]struct{}
+	globs      config.RuleIndex
+	errors     map[string]*rule.Error
+	*sync.RWMutex
+}
+
+func NewIncremental(linter *Linter, filesystem fs.FS) *Incremental {
+	return &Incremental{
+		linter:     linter,
+		filesystem: filesystem,
+		errors:     make(map[string]*rule.Error),
+		RWMutex:    new(sync.RWMutex),
+	}
+}
+
+// Init lints the whole filesystem
+func (incremental *Incremental) Init() (err error) {
+	incremental.Lock()
+	defer incremental.Unlock()
+
+	return incremental.init()
+}
+
+func (incremental *Incremental) init() (err error) {
+	var index config.RuleIndex
+	if index, err = incremental.linter.config.GetIndex(incremental.linter.config.GetLs()); err != nil {
+		return err
+	}
+
+	static := make(map[string]struct{}, len(index))
+	globs := make(config.RuleIndex)
+	for key, value := range index {
+		if strings.ContainsAny(key, "*{}") {
+			globs[key] = value
+			continue
+		}
+
+		static[key] = struct{}{}
+	}
+
+	var state *state
+	if state, err = incremental.linter.prepare(incremental.filesystem); err != nil {
+		return err
+	}
+
+	scratch := incremental.scratch()
+	if err = scratch.walk(incremental.filesystem, incremental.linter.root, state, nil, nil, false); err != nil {
+		return err
+	}
+
+	for path, pathIndex := range state.index {
+		for ext, rules := range pathIndex {
+			if err = scratch.validateExists(path, ext, rules); err != nil {
+				return err
+			}
+		}
+	}
+
+	incremental.state = state
+	incremental.static = static
+	incremental.globs = globs
+	incremental.errors = make(map[string]*rule.Error)
+	incremental.add(scratch.GetErrors())
+
+	return nil
+}
+
+// GetErrors returns the current errors sorted by path
+func (incremental *Incremental) GetErrors() []*rule.Error {
+	incremental.RLock()
+	defer incremental.RUnlock()
+
+	return sortErrors(slices.Collect(maps.Values(incremental.errors)))
+}
+
+// ShouldIgnore reports whether the path or one of its parent directories is ignored
+func (incremental *Incremental) ShouldIgnore(path string, isDir bool) bool {
+	incremental.RLock()
+	defer incremental.RUnlock()
+
+	return incremental.shouldIgnore(path, isDir)
+}
+
+func (incremental *Incremental) shouldIgnore(p string, isDir bool) bool {
+	if incremental.state == nil {
+		return false
+	}
+
+	for ; p != "." && p != "/" && p != ""; p, isDir = path.Dir(p), true {
+		if incremental.linter.shouldIgnore(incremental.state, p, isDir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reload replaces the config and lints the whole filesystem again
+func (incremental *Incremental) Reload(config *config.Config) (introduced []*rule.Error, resolved []*rule.Error, err error) {
+	incremental.Lock()
+	defer incremental.Unlock()
+
+	before := maps.Clone(incremental.errors)
+	previous := incremental.linter
+	incremental.linter = NewLinter(previous.root, config, previous.GetStatistics(), make([]*rule.Error, 0))
+
+	if err = incremental.init(); err != nil {
+		incremental.linter = previous
+		return nil, nil, err
+	}
+
+	introduced, resolved = diffErrors(before, incremental.errors)
+	return introduced, resolved, nil
+}
+
+// Update re-validates the created, removed or renamed paths
+// and returns the newly introduced and the resolved errors
+// changes of the root, .gitignore or nested .ls-lint.yml files lint the whole filesystem again
+func (incremental *Incremental) Update(paths []string) (introduced []*rule.Error, resolved []*rule.Error, err error) {
+	incremental.Lock()
+	defer incremental.Unlock()
+
+	before := maps.Clone(incremental.errors)
+
+	for _, p := range paths {
+		switch path.Base(p) {
+		case ".", gitignore.File, configFile:
+			if err = incremental.init(); err != nil {
+				return nil, nil, err
+			}
+
+			introduced, resolved = diffErrors(before, incremental.errors)
+			return introduced, resolved, nil
+		}
+	}
+
+	for indexDir := range incremental.state.index {
+		for _, p := range paths {
+			if within(indexDir, path.Clean(p)) || indexDir == path.Dir(p) || indexDir == "" && path.Dir(p) == "." {
+				incremental.reset(indexDir)
+				break
+			}
+		}
+	}
+
+	existsIndexDirs := make(map[string]struct{})
+	for _, p := range paths {
+		p = path.Clean(p)
+
+		for key, ruleErr := range incremental.errors {
+			if !ruleErr.IsDir() && within(ruleErr.GetPath(), p) {
+				delete(incremental.errors, key)
+			}
+		}
+
+		if parent := path.Dir(p); parent != "." {
+			existsIndexDirs[parent] = struct{}{}
+		} else {
+			existsIndexDirs[""] = struct{}{}
+		}
+
+		var info fs.FileInfo
+		if info, err = fs.Stat(incremental.filesystem, p); err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, nil, err
+			}
+
+			// removed dirs remove their glob expansions
+			for indexDir := range incremental.state.index {
+				if !within(indexDir, p) {
+					continue
+				}
+
+				existsIndexDirs[indexDir] = struct{}{}
+				if _, ok := incremental.static[indexDir]; !ok {
+					delete(incremental.state.index, indexDir)
+				}
+			}
+
+			continue
+		}
+
+		if incremental.shouldIgnore(p, info.IsDir()) {
+			continue
+		}
+
+		if info.IsDir() {
+			if err = incremental.expand(p); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		scratch := incremental.scratch()
+		if err = scratch.walk(incremental.filesystem, p, incremental.state, nil, nil, false); err != nil {
+			return nil, nil, err
+		}
+
+		incremental.add(scratch.GetErrors())
+
+		for indexDir := range incremental.state.index {
+			if within(indexDir, p) {
+				existsIndexDirs[indexDir] = struct{}{}
+			}
+		}
+	}
+
+	for indexDir := range existsIndexDirs {
+		if err = incremental.recount(indexDir); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, p := range paths {
+		if err = incremental.collide(path.Dir(path.Clean(p))); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	introduced, resolved = diffErrors(before, incremental.errors)
+	return introduced, resolved, nil
+}
+
+// expand adds the glob expansions of the created dir and its subdirectories to the index
+func (incremental *Incremental) expand(root string) error {
+	if len(incremental.globs) == 0 {
+		return nil
+	}
+
+	return fs.WalkDir(incremental.filesystem, root, func(p string, info fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		for key, value := range incremental.globs {
+			if _, ok := incremental.state.index[p]; ok {
+				break
+			}
+
+			if match, _ := doublestar.Match(key, p); !match {
+				continue
+			}
+
+			valueCopy := make(map[string][]rule.Rule, len(value))
+			for ext, rules := range value {
+				valueCopy[ext] = make([]rule.Rule, len(rules))
+				for i, r := range rules {
+					valueCopy[ext][i] = r.Copy()
+				}
+			}
+
+			incremental.state.index[p] = valueCopy
+		}
+
+		return nil
+	})
+}
+
+// recount resets and counts the exists rules of the index dir
+func (incremental *Incremental) recount(indexDir string) (err error) {
+	for key, ruleErr := range incremental.errors {
+		if ruleErr.IsDir() && ruleErr.GetPath() == indexDir {
+			delete(incremental.errors, key)
+		}
+	}
+
+	pathIndex, ok := incremental.state.index[indexDir]
+	if !ok {
+		return nil
+	}
+
+	incremental.reset(indexDir)
+
+	dirPath := indexDir
+	if dirPath == "" {
+		dirPath = incremental.linter.root
+	}
+
+	scratch := incremental.scratch()
+	if info, statErr := fs.Stat(incremental.filesystem, dirPath); statErr == nil && info.IsDir() && !incremental.shouldIgnore(dirPath, true) {
+		if _, _, err = scratch.validateDir(incremental.state, dirPath, true); err != nil {
+			return err
+		}
+
+		var entries []fs.DirEntry
+		if entries, err = fs.ReadDir(incremental.filesystem, dirPath); err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			entryPath := entry.Name()
+			if indexDir != "" {
+				entryPath = fmt.Sprintf("%s/%s", indexDir, entry.Name())
+			}
+
+			if incremental.shouldIgnore(entryPath, false) {
+				continue
+			}
+
+			if _, _, err = scratch.validateFile(incremental.state, entryPath, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	// only exists errors are counted, path errors of the dir are validated by the walk
+	scratch.errors = make([]*rule.Error, 0)
+	for ext, rules := range pathIndex {
+		if err = scratch.validateExists(indexDir, ext, rules); err != nil {
+			return err
+		}
+	}
+
+	incremental.add(scratch.GetErrors())
+
+	return nil
+}
+
+// collide validates the case-folded names of the entries of the dir again
+func (incremental *Incremental) collide(dirPath string) (err error) {
+	for key, ruleErr := range incremental.errors {
+		if _, ok := rule.Unwrap(ruleErr.GetRules()[0]).(*rule.UniqueCasefold); ok && ruleErr.GetPath() == dirPath {
+			delete(incremental.errors, key)
+		}
+	}
+
+	info, statErr := fs.Stat(incremental.filesystem, dirPath)
+	if statErr != nil || !info.IsDir() || incremental.shouldIgnore(dirPath, true) {
+		return nil
+	}
+
+	scratch := incremental.scratch()
+	if err = scratch.validateCollisions(incremental.filesystem, incremental.state, dirPath); err != nil {
+		return err
+	}
+
+	incremental.add(scratch.GetErrors())
+
+	return nil
+}
+
+// reset replaces the exists rules of the index dir with uncounted copies
+// the rules of reported errors keep their counts
+func (incremental *Incremental) reset(indexDir string) {
+	for ext, rules := range incremental.state.index[indexDir] {
+		rules = slices.Clone(rules)
+		for i, r := range rules {
+			if r.GetName() == "exists" {
+				rules[i] = r.Copy()
+			}
+		}
+
+		incremental.state.index[indexDir][ext] = rules
+	}
+}
+
+func (incremental *Incremental) scratch() *Linter {
+	return NewLinter(incremental.linter.root, incremental.linter.config, debug.NewStatistic(), make([]*rule.Error, 0))
+}
+
+func (incremental *Incremental) add(ruleErrors []*rule.Error) {
+	for _, ruleErr := range ruleErrors {
+		incremental.errors[errorKey(ruleErr)] = ruleErr
+	}
+}
+
+func errorKey(ruleErr *rule.Error) string {
+	names := make([]string, 0, len(ruleErr.GetRules()))
+	for _, r := range ruleErr.GetRules() {
+		names = append(names, strings.Join(append([]string{r.GetName()}, r.GetParameters()...), ":"))
+	}
+
+	return fmt.Sprintf("%s\x00%t\x00%s\x00%s", ruleErr.GetPath(), ruleErr.IsDir(), ruleErr.GetExt(), strings.Join(names, " | "))
+}
+
+func diffErrors(before map[string]*rule.Error, after map[string]*rule.Error) (introduced []*rule.Error, resolved []*rule.Error) {
+	for key, ruleErr := range after {
+		if _, ok := before[key]; !ok {
+			introduced = append(introduced, ruleErr)
+		}
+	}
+
+	for key, ruleErr := range before {
+		if _, ok := after[key]; !ok {
+			resolved = append(resolved, ruleErr)
+		}
+	}
+
+	return sortErrors(introduced), sortErrors(resolved)
+}
+
+func sortErrors(ruleErrors []*rule.Error) []*rule.Error {
+	slices.SortFunc(ruleErrors, func(a, b *rule.Error) int {
+		if c := strings.Compare(a.GetPath(), b.GetPath()); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.GetExt(), b.GetExt())
+	})
+
+	return ruleErrors
+}
+
+// within reports whether the path is the root or inside of it
+func within(p string, root string) bool {
+	return p == root || strings.HasPrefix(p, root+"/")
+}
@@ -0,0 +1,166 @@
+package linter
+
+import (
+	"io/fs"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/config"
+	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+func TestIncremental_Update(t *testing.T) {
+	var tests = []*struct {
+		description        string
+		filesystem         fstest.MapFS
+		ls                 config.Ls
+		ignore             []string
+		create             fstest.MapFS
+		remove             []string
+		expectedErrors     []string
+		expectedIntroduced []string
+		expectedResolved   []string
+	}{
+		{
+			description:        "created file",
+			filesystem:         fstest.MapFS{"src": &fstest.MapFile{Mode: fs.ModeDir}},
+			ls:                 config.Ls{".ts": "kebab-case"},
+			create:             fstest.MapFS{"src/NotKebab.ts": &fstest.MapFile{Mode: fs.ModePerm}},
+			expectedIntroduced: []string{"src/NotKebab.ts"},
+		},
+		{
+			description: "renamed file",
+			filesystem: fstest.MapFS{
+				"src":             &fstest.MapFile{Mode: fs.ModeDir},
+				"src/NotKebab.ts": &fstest.MapFile{Mode: fs.ModePerm},
+			},
+			ls:               config.Ls{".ts": "kebab-case"},
+			create:           fstest.MapFS{"src/not-kebab.ts": &fstest.MapFile{Mode: fs.ModePerm}},
+			remove:           []string{"src/NotKebab.ts"},
+			expectedErrors:   []string{"src/NotKebab.ts"},
+			expectedResolved: []string{"src/NotKebab.ts"},
+		},
+		{
+			description: "exists",
+			filesystem: fstest.MapFS{
+				"src":         &fstest.MapFile{Mode: fs.ModeDir},
+				"src/main.go": &fstest.MapFile{Mode: fs.ModePerm},
+			},
+			ls:                 config.Ls{"src": config.Ls{".go": "exists:1"}},
+			create:             fstest.MapFS{"src/util.go": &fstest.MapFile{Mode: fs.ModePerm}},
+			expectedIntroduced: []string{"src"},
+		},
+		{
+			description: "removed dir",
+			filesystem: fstest.MapFS{
+				"src":                  &fstest.MapFile{Mode: fs.ModeDir},
+				"src/Bad":              &fstest.MapFile{Mode: fs.ModeDir},
+				"src/Bad/NotKebab.ts":  &fstest.MapFile{Mode: fs.ModePerm},
+				"src/Bad/not-kebab.ts": &fstest.MapFile{Mode: fs.ModePerm},
+			},
+			ls:               config.Ls{"src/*": config.Ls{".dir": "kebab-case", ".ts": "kebab-case | exists:1"}},
+			remove:           []string{"src/Bad", "src/Bad/NotKebab.ts", "src/Bad/not-kebab.ts"},
+			expectedErrors:   []string{"src/Bad", "src/Bad", "src/Bad/NotKebab.ts"},
+			expectedResolved: []string{"src/Bad", "src/Bad", "src/Bad/NotKebab.ts"},
+		},
+		{
+			description: "created glob dir",
+			filesystem: fstest.MapFS{
+				"src":          &fstest.MapFile{Mode: fs.ModeDir},
+				"src/app":      &fstest.MapFile{Mode: fs.ModeDir},
+				"src/app/a.ts": &fstest.MapFile{Mode: fs.ModePerm},
+			},
+			ls:                 config.Ls{"src/*": config.Ls{".ts": "exists:1"}},
+			create:             fstest.MapFS{"src/lib": &fstest.MapFile{Mode: fs.ModeDir}},
+			expectedIntroduced: []string{"src/lib"},
+		},
+		{
+			description: "case collision",
+			filesystem: fstest.MapFS{
+				"src":           &fstest.MapFile{Mode: fs.ModeDir},
+				"src/Button.ts": &fstest.MapFile{Mode: fs.ModePerm},
+				"src/Input.ts":  &fstest.MapFile{Mode: fs.ModePerm},
+				"src/input.ts":  &fstest.MapFile{Mode: fs.ModePerm},
+			},
+			ls:                 config.Ls{".dir": "unique-casefold"},
+			create:             fstest.MapFS{"src/button.ts": &fstest.MapFile{Mode: fs.ModePerm}},
+			remove:             []string{"src/input.ts"},
+			expectedErrors:     []string{"src"},
+			expectedIntroduced: []string{"src"},
+			expectedResolved:   []string{"src"},
+		},
+		{
+			description:    "ignored",
+			filesystem:     fstest.MapFS{"dist": &fstest.MapFile{Mode: fs.ModeDir}},
+			ls:             config.Ls{".ts": "kebab-case"},
+			ignore:         []string{"dist"},
+			create:         fstest.MapFS{"dist/NotKebab.ts": &fstest.MapFile{Mode: fs.ModePerm}},
+			expectedErrors: []string{},
+		},
+	}
+
+	var i = 0
+	for _, test := range tests {
+		incremental := NewIncremental(NewLinter(
+			".",
+			&config.Config{
+				Ls:      test.ls,
+				Ignore:  test.ignore,
+				RWMutex: new(sync.RWMutex),
+			},
+			debug.NewStatistic(),
+			make([]*rule.Error, 0),
+		), test.filesystem)
+
+		if err := incremental.Init(); err != nil {
+			t.Errorf("Test %d (%s) failed with unmatched error value - %v", i, test.description, err)
+			return
+		}
+
+		if paths := errorPaths(incremental.GetErrors()); len(paths)+len(test.expectedErrors) > 0 && !reflect.DeepEqual(paths, test.expectedErrors) {
+			t.Errorf("Test %d (%s) failed with unmatched errors value - %+v", i, test.description, paths)
+			return
+		}
+
+		for _, path := range test.remove {
+			delete(test.filesystem, path)
+		}
+
+		paths := slices.Clone(test.remove)
+		for path, file := range test.create {
+			test.filesystem[path] = file
+			paths = append(paths, path)
+		}
+
+		introduced, resolved, err := incremental.Update(paths)
+		if err != nil {
+			t.Errorf("Test %d (%s) failed with unmatched error value - %v", i, test.description, err)
+			return
+		}
+
+		if paths := errorPaths(introduced); len(paths)+len(test.expectedIntroduced) > 0 && !reflect.DeepEqual(paths, test.expectedIntroduced) {
+			t.Errorf("Test %d (%s) failed with unmatched introduced value - %+v", i, test.description, paths)
+			return
+		}
+
+		if paths := errorPaths(resolved); len(paths)+len(test.expectedResolved) > 0 && !reflect.DeepEqual(paths, test.expectedResolved) {
+			t.Errorf("Test %d (%s) failed with unmatched resolved value - %+v", i, test.description, paths)
+			return
+		}
+
+		i++
+	}
+}
+
+func errorPaths(ruleErrors []*rule.Error) []string {
+	paths := make([]string, 0, len(ruleErrors))
+	for _, ruleErr := range ruleErrors {
+		paths = append(paths, ruleErr.GetPath())
+	}
+
+	return paths
+}
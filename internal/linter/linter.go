@@ -1,31 +1,38 @@
 package linter
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"math"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/loeffel-io/ls-lint/v2/internal/config"
 	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/gitignore"
 	"github.com/loeffel-io/ls-lint/v2/internal/glob"
 	"github.com/loeffel-io/ls-lint/v2/internal/rule"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	extSep = "."
-	dir    = ".dir"
+	extSep     = "."
+	dir        = ".dir"
+	configFile = ".ls-lint.yml"
 )
 
 type Linter struct {
-	root      string
-	config    *config.Config
-	statistic *debug.Statistic
-	errors    []*rule.Error
+	root       string
+	config     *config.Config
+	statistic  *debug.Statistic
+	errors     []*rule.Error
+	exceptions []*exception
+	linted     map[string][]string
 	*sync.RWMutex
 }
 
@@ -53,6 +60,53 @@ func (linter *Linter) GetErrors() []*rule.Error {
 	return linter.errors
 }
 
+// GetLintedPaths returns the validated paths grouped by the dir of their rule index
+func (linter *Linter) GetLintedPaths() map[string][]string {
+	linter.RLock()
+	defer linter.RUnlock()
+
+	return linter.linted
+}
+
+// addLinted records the path if its extension has rules
+func (linter *Linter) addLinted(state *state, indexDir string, path string, ext string) {
+	if _, rules := linter.config.GetConfig(state.index, path); len(rules[ext]) == 0 {
+		return
+	}
+
+	linter.Lock()
+	defer linter.Unlock()
+
+	if linter.linted == nil {
+		linter.linted = make(map[string][]string)
+	}
+
+	linter.linted[indexDir] = append(linter.linted[indexDir], path)
+}
+
+func (linter *Linter) setExceptions(exceptions []*exception) {
+	linter.Lock()
+	defer linter.Unlock()
+
+	linter.exceptions = exceptions
+}
+
+// GetUnusedExceptions returns the patterns of the exceptions which didn't exempt any path from a failing rule
+// only runs without paths lint all exempted paths
+func (linter *Linter) GetUnusedExceptions() []string {
+	linter.RLock()
+	defer linter.RUnlock()
+
+	unused := make([]string, 0)
+	for _, e := range linter.exceptions {
+		if !e.isUsed() {
+			unused = append(unused, e.pattern)
+		}
+	}
+
+	return unused
+}
+
 func (linter *Linter) AddError(error *rule.Error) {
 	linter.Lock()
 	defer linter.Unlock()
@@ -60,8 +114,8 @@ func (linter *Linter) AddError(error *rule.Error) {
 	linter.errors = append(linter.errors, error)
 }
 
-func (linter *Linter) validateDir(index config.RuleIndex, path string, validate bool) (string, string, error) {
-	indexDir, rules := linter.config.GetConfig(index, path)
+func (linter *Linter) validateDir(state *state, path string, validate bool) (string, string, error) {
+	indexDir, rules := linter.config.GetConfig(state.index, path)
 
 	if !validate {
 		return indexDir, dir, nil
@@ -88,13 +142,22 @@ func (linter *Linter) validateDir(index config.RuleIndex, path string, validate
 		return indexDir, dir, nil
 	}
 
-	for _, ruleDir := range rules[dir] {
+	valueOf := func(rule.Rule) string {
+		return basename
+	}
+
+	dirRules, err := except(state.exceptions, path, rules[dir], valueOf, pathDir)
+	if err != nil {
+		return indexDir, dir, err
+	}
+
+	for _, ruleDir := range dirRules {
 		g.Go(func() error {
 			if ruleDir.GetName() == "exists" && pathDir != indexDir {
 				return nil
 			}
 
-			valid, err := ruleDir.Validate(basename, pathDir, ruleDir.GetName() != "exists")
+			valid, err := rule.Evaluate(ruleDir, valueOf, pathDir, ruleDir.GetName() != "exists")
 			if err != nil {
 				return err
 			}
@@ -121,17 +184,19 @@ func (linter *Linter) validateDir(index config.RuleIndex, path string, validate
 	}
 
 	linter.AddError(&rule.Error{
-		Path:    path,
-		Ext:     dir,
-		Rules:   rules[dir],
-		RWMutex: new(sync.RWMutex),
+		Path:     path,
+		Ext:      dir,
+		IndexDir: indexDir,
+		Rules:    explain(dirRules, valueOf, func(rule.Rule) string { return "" }, pathDir),
+		RWMutex:  new(sync.RWMutex),
 	})
 
 	return indexDir, dir, nil
 }
 
-func (linter *Linter) validateFile(index config.RuleIndex, path string, validate bool) (string, string, error) {
+func (linter *Linter) validateFile(state *state, path string, validate bool) (string, string, error) {
 	var ext string
+	var fileRules []rule.Rule
 	g := new(errgroup.Group)
 
 	var rulesNonExclusiveCount int8
@@ -139,7 +204,7 @@ func (linter *Linter) validateFile(index config.RuleIndex, path string, validate
 	rulesMutex := new(sync.Mutex)
 
 	exts := strings.Split(filepath.Base(path), extSep)[1:]
-	indexDir, rules := linter.config.GetConfig(index, path)
+	indexDir, rules := linter.config.GetConfig(state.index, path)
 
 	var pathDir string
 	pathDir = filepath.ToSlash(filepath.Dir(path)); // compatibility with windows
@@ -151,6 +216,19 @@ func (linter *Linter) validateFile(index config.RuleIndex, path string, validate
 	maxCombinations := int(math.Pow(2, float64(n))) // 2^N combinations
 
 	var withoutExt string
+	valueOf := func(r rule.Rule) string {
+		if basenameRule, ok := r.(rule.BasenameRule); ok && basenameRule.UseBasename() {
+			return filepath.Base(path)
+		}
+
+		return withoutExt
+	}
+
+	// the extensions are the rest of the name for all rules which don't validate the basename
+	restOf := func(r rule.Rule) string {
+		return strings.TrimPrefix(filepath.Base(path), valueOf(r))
+	}
+
 	for i := 0; i < maxCombinations; i++ {
 		combination := make([]string, n)
 		for j := 0; j < n; j++ {
@@ -168,7 +246,15 @@ func (linter *Linter) validateFile(index config.RuleIndex, path string, validate
 		}
 
 		if _, ok := rules[ext]; ok {
-			for _, ruleFile := range rules[ext] {
+			fileRules = rules[ext]
+			if validate {
+				var err error
+				if fileRules, err = except(state.exceptions, path, fileRules, valueOf, pathDir); err != nil {
+					return indexDir, ext, err
+				}
+			}
+
+			for _, ruleFile := range fileRules {
 				if !validate && ruleFile.GetName() != "exists" {
 					continue
 				}
@@ -178,7 +264,7 @@ func (linter *Linter) validateFile(index config.RuleIndex, path string, validate
 						return nil
 					}
 
-					valid, err := ruleFile.Validate(withoutExt, pathDir, ruleFile.GetName() != "exists")
+					valid, err := rule.Evaluate(ruleFile, valueOf, pathDir, ruleFile.GetName() != "exists")
 					if err != nil {
 						return err
 					}
@@ -209,101 +295,254 @@ func (linter *Linter) validateFile(index config.RuleIndex, path string, validate
 	}
 
 	linter.AddError(&rule.Error{
-		Path:    path,
-		Dir:     false,
-		Ext:     ext,
-		Rules:   rules[ext],
-		RWMutex: new(sync.RWMutex),
+		Path:     path,
+		Dir:      false,
+		Ext:      ext,
+		IndexDir: indexDir,
+		Rules:    explain(fileRules, valueOf, restOf, pathDir),
+		RWMutex:  new(sync.RWMutex),
 	})
 
 	return indexDir, ext, nil
 }
 
-func (linter *Linter) Run(filesystem fs.FS, paths map[string]struct{}, debug bool) (err error) {
-	var pathsIndex map[string]map[string]struct{} = nil
-	if len(paths) > 0 {
-		pathsIndex = make(map[string]map[string]struct{})
+// explain replaces the rules which can explain their failure with explained copies
+// expressions only keep their failed operands
+// exclusive rules except exists are validated separately (e.g. unique-casefold) and are left out
+func explain(rules []rule.Rule, valueOf rule.ValueFunc, restOf rule.ValueFunc, path string) []rule.Rule {
+	explained := make([]rule.Rule, 0, len(rules))
+	for _, r := range rules {
+		if r.GetExclusive() && r.GetName() != "exists" {
+			continue
+		}
+
+		if expression, ok := rule.Unwrap(r).(*rule.Expression); ok {
+			var explainedRule rule.Rule = expression.ExplainWith(valueOf, restOf, path)
+			if annotated, ok := r.(*rule.Annotated); ok {
+				explainedRule = annotated.With(explainedRule)
+			}
+
+			explained = append(explained, explainedRule)
+			continue
+		}
+
+		explained = append(explained, rule.ExplainRule(r, valueOf(r), restOf(r)))
 	}
 
-	// create index
-	var index config.RuleIndex
-	if index, err = linter.config.GetIndex(linter.config.GetLs()); err != nil {
+	return explained
+}
+
+// validateCollisions groups the entries of the dir by their case-folded name if the dir has a unique-casefold rule
+// every group of colliding entries is reported as a single error
+func (linter *Linter) validateCollisions(filesystem fs.FS, state *state, path string) error {
+	indexDir, rules := linter.config.GetConfig(state.index, path)
+
+	var configured rule.Rule
+	var uniqueCasefold *rule.UniqueCasefold
+	for _, r := range rules[dir] {
+		if tmpRule, ok := rule.Unwrap(r).(*rule.UniqueCasefold); ok {
+			configured, uniqueCasefold = r, tmpRule
+		}
+	}
+
+	if uniqueCasefold == nil {
+		return nil
+	}
+
+	entries, err := fs.ReadDir(filesystem, path)
+	if err != nil {
 		return err
 	}
 
-	// glob index
-	if err = glob.Index(filesystem, index, false); err != nil {
+	groups := make(map[string][]string)
+	for _, entry := range entries {
+		entryPath := entry.Name()
+		if path != linter.root {
+			entryPath = fmt.Sprintf("%s/%s", path, entry.Name())
+		}
+
+		if linter.shouldIgnore(state, entryPath, entry.IsDir()) {
+			continue
+		}
+
+		key := rule.Casefold(entry.Name())
+		groups[key] = append(groups[key], entry.Name())
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		if len(groups[key]) < 2 {
+			continue
+		}
+
+		collision := uniqueCasefold.WithCollisions(slices.Sorted(slices.Values(groups[key])))
+		if annotated, ok := configured.(*rule.Annotated); ok {
+			collision = annotated.With(collision)
+		}
+
+		linter.AddError(&rule.Error{
+			Path:     path,
+			Dir:      false,
+			Ext:      dir,
+			IndexDir: indexDir,
+			Rules:    []rule.Rule{collision},
+			RWMutex:  new(sync.RWMutex),
+		})
+	}
+
+	return nil
+}
+
+// loadNestedConfig loads the .ls-lint.yml of the directory if exists
+// ls and ignore entries are scoped to the directory and take precedence over the existing index
+func (linter *Linter) loadNestedConfig(filesystem fs.FS, state *state, path string, debug bool) (err error) {
+	configPath := fmt.Sprintf("%s/%s", path, configFile)
+
+	var data []byte
+	if data, err = fs.ReadFile(filesystem, configPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
 		return err
 	}
 
-	// glob ignore index
-	ignoreIndex := linter.config.GetIgnoreIndex()
-	if err = glob.IgnoreIndex(filesystem, ignoreIndex, true); err != nil {
+	var nestedConfig *config.Config
+	if nestedConfig, err = config.ParseWithRules(func(name string) ([]byte, error) {
+		return fs.ReadFile(filesystem, name)
+	}, configPath, data, linter.config.GetRules()); err != nil {
 		return err
 	}
 
-	if debug {
-		fmt.Printf("=============================\nls index\n-----------------------------\n")
-		for path, pathIndex := range index {
-			switch path == "" {
-			case true:
-				fmt.Printf(".:")
-			case false:
-				fmt.Printf("%s:", path)
-			}
+	var subFilesystem fs.FS
+	if subFilesystem, err = fs.Sub(filesystem, path); err != nil {
+		return err
+	}
 
-			for ext, rules := range pathIndex {
-				tmpRules := make([]string, 0)
-				for _, tmpRule := range rules {
-					if len(tmpRule.GetParameters()) > 0 {
-						tmpRules = append(tmpRules, fmt.Sprintf("%s:%s", tmpRule.GetName(), strings.Join(tmpRule.GetParameters(), ",")))
-						continue
-					}
+	var nestedIndex config.RuleIndex
+	if nestedIndex, err = linter.config.GetIndex(nestedConfig.GetLs()); err != nil {
+		return fmt.Errorf("%s: %s", configPath, err.Error())
+	}
 
-					tmpRules = append(tmpRules, tmpRule.GetName())
-				}
+	if err = glob.Index(subFilesystem, nestedIndex, false); err != nil {
+		return err
+	}
 
-				fmt.Printf(" %s: %s", ext, strings.Join(tmpRules, ", "))
-			}
-			fmt.Printf("\n")
+	for key, exts := range nestedIndex {
+		nestedKey := path
+		if key != "" {
+			nestedKey = fmt.Sprintf("%s/%s", path, key)
 		}
 
-		fmt.Printf("-----------------------------\nignore index\n-----------------------------\n")
-		for path := range ignoreIndex {
-			fmt.Printf("%s\n", path)
+		if _, ok := state.index[nestedKey]; !ok {
+			state.index[nestedKey] = make(map[string][]rule.Rule, len(exts))
 		}
 
-		fmt.Printf("-----------------------------\nlint\n-----------------------------\n")
+		for ext, rules := range exts {
+			state.index[nestedKey][ext] = rules
+		}
+	}
+
+	nestedIgnoreIndex := nestedConfig.GetIgnoreIndex()
+	if err = glob.IgnoreIndex(subFilesystem, nestedIgnoreIndex, true); err != nil {
+		return err
+	}
+
+	for key, ignore := range nestedIgnoreIndex {
+		state.ignoreIndex[fmt.Sprintf("%s/%s", path, key)] = ignore
+	}
+
+	var nestedExceptions []*exception
+	if nestedExceptions, err = newExceptions(linter.config, nestedConfig.GetExceptions(), path); err != nil {
+		return fmt.Errorf("%s: %s", configPath, err.Error())
 	}
 
+	state.exceptions = append(state.exceptions, nestedExceptions...)
+
 	if debug {
-		defer func() {
-			fmt.Printf("-----------------------------\nstatistics\n-----------------------------\n")
-			fmt.Printf("time: %s\n", time.Since(linter.GetStatistics().Start).Truncate(time.Microsecond).String())
-			fmt.Printf("paths: %d\n", linter.GetStatistics().Files)
-			fmt.Printf("file skips: %d\n", linter.GetStatistics().FileSkips)
-			fmt.Printf("dirs: %d\n", linter.GetStatistics().Dirs)
-			fmt.Printf("dir skips: %d\n", linter.GetStatistics().DirSkips)
-			fmt.Printf("=============================\n")
-		}()
+		fmt.Printf("nested config: %s\n", configPath)
 	}
 
-	if err = fs.WalkDir(filesystem, linter.root, func(path string, info fs.DirEntry, err error) error {
-		if linter.config.ShouldIgnore(ignoreIndex, path) {
+	return nil
+}
+
+// state is the glob expanded rule and ignore index of a run
+type state struct {
+	index            config.RuleIndex
+	ignoreIndex      map[string]bool
+	exceptions       []*exception
+	gitignoreMatcher *gitignore.Matcher
+}
+
+func (linter *Linter) prepare(filesystem fs.FS) (_ *state, err error) {
+	// create index
+	var index config.RuleIndex
+	if index, err = linter.config.GetIndex(linter.config.GetLs()); err != nil {
+		return nil, err
+	}
+
+	// glob index
+	if err = glob.Index(filesystem, index, false); err != nil {
+		return nil, err
+	}
+
+	// glob ignore index
+	ignoreIndex := linter.config.GetIgnoreIndex()
+	if err = glob.IgnoreIndex(filesystem, ignoreIndex, true); err != nil {
+		return nil, err
+	}
+
+	// exceptions
+	var exceptions []*exception
+	if exceptions, err = newExceptions(linter.config, linter.config.GetExceptions(), ""); err != nil {
+		return nil, err
+	}
+
+	// gitignore
+	var gitignoreMatcher *gitignore.Matcher
+	if linter.config.GetUseGitignore() {
+		gitignoreMatcher = gitignore.NewMatcher()
+	}
+
+	return &state{
+		index:            index,
+		ignoreIndex:      ignoreIndex,
+		exceptions:       exceptions,
+		gitignoreMatcher: gitignoreMatcher,
+	}, nil
+}
+
+func (linter *Linter) shouldIgnore(state *state, path string, isDir bool) bool {
+	if linter.config.ShouldIgnore(state.ignoreIndex, path) {
+		return true
+	}
+
+	return state.gitignoreMatcher != nil && state.gitignoreMatcher.Match(path, isDir)
+}
+
+func (linter *Linter) walk(filesystem fs.FS, root string, state *state, paths map[string]struct{}, pathsIndex map[string]map[string]struct{}, debug bool) error {
+	return fs.WalkDir(filesystem, root, func(path string, info fs.DirEntry, err error) error {
+		ignore := linter.config.ShouldIgnore(state.ignoreIndex, path)
+		if !ignore && state.gitignoreMatcher != nil && info != nil {
+			ignore = state.gitignoreMatcher.Match(path, info.IsDir())
+		}
+
+		if ignore {
 			if info.IsDir() {
 				if debug {
 					fmt.Printf("skip dir: %s\n", path)
-					linter.GetStatistics().AddDirSkip()
 				}
 
+				linter.GetStatistics().AddDirSkip()
+
 				return fs.SkipDir
 			}
 
 			if debug {
 				fmt.Printf("skip file: %s\n", path)
-				linter.GetStatistics().AddFileSkip()
 			}
 
+			linter.GetStatistics().AddFileSkip()
+
 			return nil
 		}
 
@@ -320,13 +559,36 @@ func (linter *Linter) Run(filesystem fs.FS, paths map[string]struct{}, debug boo
 		if info.IsDir() {
 			if debug {
 				fmt.Printf("lint dir: %s\n", path)
-				linter.GetStatistics().AddDir()
 			}
 
-			if indexDir, ext, err = linter.validateDir(index, path, validate); err != nil {
+			linter.GetStatistics().AddDir()
+
+			if state.gitignoreMatcher != nil {
+				if err = state.gitignoreMatcher.Load(filesystem, path); err != nil {
+					return err
+				}
+			}
+
+			if linter.config.GetNestedConfig() && path != linter.root {
+				if err = linter.loadNestedConfig(filesystem, state, path, debug); err != nil {
+					return err
+				}
+			}
+
+			if indexDir, ext, err = linter.validateDir(state, path, validate); err != nil {
 				return err
 			}
 
+			if validate {
+				if err = linter.validateCollisions(filesystem, state, path); err != nil {
+					return err
+				}
+
+				if path != linter.root {
+					linter.addLinted(state, indexDir, path, ext)
+				}
+			}
+
 			if pathsIndex != nil && validate {
 				if _, ok := pathsIndex[indexDir]; !ok {
 					pathsIndex[indexDir] = make(map[string]struct{})
@@ -340,13 +602,18 @@ func (linter *Linter) Run(filesystem fs.FS, paths map[string]struct{}, debug boo
 
 		if debug {
 			fmt.Printf("lint file: %s\n", path)
-			linter.GetStatistics().AddFile()
 		}
 
-		if indexDir, ext, err = linter.validateFile(index, path, validate); err != nil {
+		linter.GetStatistics().AddFile()
+
+		if indexDir, ext, err = linter.validateFile(state, path, validate); err != nil {
 			return err
 		}
 
+		if validate {
+			linter.addLinted(state, indexDir, path, ext)
+		}
+
 		if pathsIndex != nil && validate {
 			if _, ok := pathsIndex[indexDir]; !ok {
 				pathsIndex[indexDir] = make(map[string]struct{})
@@ -356,10 +623,120 @@ func (linter *Linter) Run(filesystem fs.FS, paths map[string]struct{}, debug boo
 		}
 
 		return nil
-	}); err != nil {
+	})
+}
+
+func (linter *Linter) validateExists(path string, ext string, rules []rule.Rule) error {
+	for _, r := range rules {
+		if r.GetName() != "exists" {
+			continue
+		}
+
+		valid, err := r.Validate("", "", true)
+		if err != nil {
+			return err
+		}
+
+		if !valid {
+			linter.AddError(&rule.Error{
+				Path:     path,
+				Dir:      true,
+				Ext:      ext,
+				IndexDir: path,
+				Rules:    []rule.Rule{r},
+				RWMutex:  new(sync.RWMutex),
+			})
+		}
+	}
+
+	return nil
+}
+
+func (linter *Linter) Run(filesystem fs.FS, paths map[string]struct{}, debug bool) (err error) {
+	var pathsIndex map[string]map[string]struct{} = nil
+	if len(paths) > 0 {
+		pathsIndex = make(map[string]map[string]struct{})
+	}
+
+	var state *state
+	if state, err = linter.prepare(filesystem); err != nil {
 		return err
 	}
 
+	index := state.index
+	ignoreIndex := state.ignoreIndex
+
+	if debug {
+		fmt.Printf("=============================\nls index\n-----------------------------\n")
+		for path, pathIndex := range index {
+			switch path == "" {
+			case true:
+				fmt.Printf(".:")
+			case false:
+				fmt.Printf("%s:", path)
+			}
+
+			for ext, rules := range pathIndex {
+				tmpRules := make([]string, 0)
+				for _, tmpRule := range rules {
+					if expression, ok := rule.Unwrap(tmpRule).(*rule.Expression); ok {
+						tmpRules = append(tmpRules, expression.GetErrorMessage())
+						continue
+					}
+
+					if len(tmpRule.GetParameters()) > 0 {
+						tmpRules = append(tmpRules, fmt.Sprintf("%s:%s", tmpRule.GetName(), strings.Join(tmpRule.GetParameters(), ",")))
+						continue
+					}
+
+					tmpRules = append(tmpRules, tmpRule.GetName())
+				}
+
+				fmt.Printf(" %s: %s", ext, strings.Join(tmpRules, ", "))
+			}
+			fmt.Printf("\n")
+		}
+
+		if origins := linter.config.GetOrigins(); len(origins) > 0 {
+			fmt.Printf("-----------------------------\nconfig origins\n-----------------------------\n")
+			for _, path := range slices.Sorted(maps.Keys(origins)) {
+				for _, ext := range slices.Sorted(maps.Keys(origins[path])) {
+					switch path == "" {
+					case true:
+						fmt.Printf(".: %s: %s\n", ext, origins[path][ext])
+					case false:
+						fmt.Printf("%s: %s: %s\n", path, ext, origins[path][ext])
+					}
+				}
+			}
+		}
+
+		fmt.Printf("-----------------------------\nignore index\n-----------------------------\n")
+		for path := range ignoreIndex {
+			fmt.Printf("%s\n", path)
+		}
+
+		fmt.Printf("-----------------------------\nlint\n-----------------------------\n")
+	}
+
+	if debug {
+		defer func() {
+			fmt.Printf("-----------------------------\nstatistics\n-----------------------------\n")
+			fmt.Printf("time: %s\n", time.Since(linter.GetStatistics().Start).Truncate(time.Microsecond).String())
+			fmt.Printf("paths: %d\n", linter.GetStatistics().Files)
+			fmt.Printf("file skips: %d\n", linter.GetStatistics().FileSkips)
+			fmt.Printf("dirs: %d\n", linter.GetStatistics().Dirs)
+			fmt.Printf("dir skips: %d\n", linter.GetStatistics().DirSkips)
+			fmt.Printf("=============================\n")
+		}()
+	}
+
+	if err = linter.walk(filesystem, linter.root, state, paths, pathsIndex, debug); err != nil {
+		return err
+	}
+
+	linter.setExceptions(state.exceptions)
+
 	// validate exists
 	for path, pathIndex := range index {
 		for ext, rules := range pathIndex {
@@ -367,25 +744,8 @@ func (linter *Linter) Run(filesystem fs.FS, paths map[string]struct{}, debug boo
 				continue
 			}
 
-			for _, r := range rules {
-				if r.GetName() != "exists" {
-					continue
-				}
-
-				var valid bool
-				if valid, err = r.Validate("", "", true); err != nil {
-					return err
-				}
-
-				if !valid {
-					linter.AddError(&rule.Error{
-						Path:    path,
-						Dir:     true,
-						Ext:     ext,
-						Rules:   []rule.Rule{r},
-						RWMutex: new(sync.RWMutex),
-					})
-				}
+			if err = linter.validateExists(path, ext, rules); err != nil {
+				return err
 			}
 		}
 	}
@@ -359,6 +359,581 @@ func TestLinter_Run(t *testing.T) {
 			},
 			expectedErrors: []*rule.Error{},
 		},
+		{
+			description: "gitignore",
+			filesystem: fstest.MapFS{
+				".gitignore":             &fstest.MapFile{Mode: fs.ModePerm, Data: []byte("dist/\n*.gen.png\n")},
+				"snake_case.png":         &fstest.MapFile{Mode: fs.ModePerm},
+				"Not-Snake.gen.png":      &fstest.MapFile{Mode: fs.ModePerm},
+				"dist":                   &fstest.MapFile{Mode: fs.ModeDir},
+				"dist/Not-Snake.png":     &fstest.MapFile{Mode: fs.ModePerm},
+				"src":                    &fstest.MapFile{Mode: fs.ModeDir},
+				"src/.gitignore":         &fstest.MapFile{Mode: fs.ModePerm, Data: []byte("*.png\n!keep_*.png\n")},
+				"src/Not-Snake.png":      &fstest.MapFile{Mode: fs.ModePerm},
+				"src/keep_Not-Snake.png": &fstest.MapFile{Mode: fs.ModePerm},
+			},
+			paths: nil,
+			linter: NewLinter(
+				".",
+				&config.Config{
+					Ls: config.Ls{
+						".png": "snake_case",
+					},
+					Ignore:       []string{".gitignore", "src/.gitignore"},
+					UseGitignore: true,
+					RWMutex:      new(sync.RWMutex),
+				},
+				&debug.Statistic{
+					Start:     start,
+					Files:     0,
+					FileSkips: 0,
+					Dirs:      0,
+					DirSkips:  0,
+					RWMutex:   new(sync.RWMutex),
+				},
+				[]*rule.Error{},
+			),
+			expectedErr: nil,
+			expectedStatistic: &debug.Statistic{
+				Start:     start,
+				Files:     2,
+				FileSkips: 4,
+				Dirs:      2,
+				DirSkips:  1,
+				RWMutex:   new(sync.RWMutex),
+			},
+			expectedErrors: []*rule.Error{
+				{
+					Path: "src/keep_Not-Snake.png",
+					Ext:  ".png",
+					Rules: []rule.Rule{
+						new(rule.SnakeCase).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+			},
+		},
+		{
+			description: "nested config",
+			filesystem: fstest.MapFS{
+				"snake_case.png":                      &fstest.MapFile{Mode: fs.ModePerm},
+				"packages":                            &fstest.MapFile{Mode: fs.ModeDir},
+				"packages/a":                          &fstest.MapFile{Mode: fs.ModeDir},
+				"packages/a/.ls-lint.yml":             &fstest.MapFile{Mode: fs.ModePerm, Data: []byte("ls:\n  .png: kebab-case\n  generated:\n    .png: PascalCase\nignore:\n  - dist\n")},
+				"packages/a/kebab-case.png":           &fstest.MapFile{Mode: fs.ModePerm},
+				"packages/a/not_kebab_case.png":       &fstest.MapFile{Mode: fs.ModePerm},
+				"packages/a/dist":                     &fstest.MapFile{Mode: fs.ModeDir},
+				"packages/a/dist/Ignored.png":         &fstest.MapFile{Mode: fs.ModePerm},
+				"packages/a/generated":                &fstest.MapFile{Mode: fs.ModeDir},
+				"packages/a/generated/PascalCase.png": &fstest.MapFile{Mode: fs.ModePerm},
+				"packages/a/sub":                      &fstest.MapFile{Mode: fs.ModeDir},
+				"packages/a/sub/kebab-case.png":       &fstest.MapFile{Mode: fs.ModePerm},
+				"packages/b":                          &fstest.MapFile{Mode: fs.ModeDir},
+				"packages/b/kebab-case.png":           &fstest.MapFile{Mode: fs.ModePerm},
+				"packages/B":                          &fstest.MapFile{Mode: fs.ModeDir},
+			},
+			paths: nil,
+			linter: NewLinter(
+				".",
+				&config.Config{
+					Ls: config.Ls{
+						".png": "snake_case",
+						".yml": "exists:0",
+						"packages": config.Ls{
+							".dir": "snake_case",
+						},
+					},
+					Ignore:       []string{},
+					NestedConfig: true,
+					RWMutex:      new(sync.RWMutex),
+				},
+				&debug.Statistic{
+					Start:     start,
+					Files:     0,
+					FileSkips: 0,
+					Dirs:      0,
+					DirSkips:  0,
+					RWMutex:   new(sync.RWMutex),
+				},
+				[]*rule.Error{},
+			),
+			expectedErr: nil,
+			expectedStatistic: &debug.Statistic{
+				Start:     start,
+				Files:     7,
+				FileSkips: 0,
+				Dirs:      7,
+				DirSkips:  1,
+				RWMutex:   new(sync.RWMutex),
+			},
+			expectedErrors: []*rule.Error{
+				{
+					Path: "packages/a/not_kebab_case.png",
+					Ext:  ".png",
+					Rules: []rule.Rule{
+						new(rule.KebabCase).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "packages/B",
+					Ext:  ".dir",
+					Rules: []rule.Rule{
+						new(rule.SnakeCase).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+			},
+		},
+		{
+			description: "length",
+			filesystem: fstest.MapFS{
+				"a.ts":        &fstest.MapFile{Mode: fs.ModePerm},
+				"too_long.ts": &fstest.MapFile{Mode: fs.ModePerm},
+				"a.md":        &fstest.MapFile{Mode: fs.ModePerm},
+				"README.md":   &fstest.MapFile{Mode: fs.ModePerm},
+				"src":         &fstest.MapFile{Mode: fs.ModeDir},
+				"src/abcd":    &fstest.MapFile{Mode: fs.ModeDir},
+			},
+			paths: nil,
+			linter: NewLinter(
+				".",
+				&config.Config{
+					Ls: config.Ls{
+						".dir": "length:1-3",
+						".ts":  "length:1-5",
+						".md":  "length:1-6:ext",
+					},
+					Ignore:  []string{},
+					RWMutex: new(sync.RWMutex),
+				},
+				&debug.Statistic{
+					Start:     start,
+					Files:     0,
+					FileSkips: 0,
+					Dirs:      0,
+					DirSkips:  0,
+					RWMutex:   new(sync.RWMutex),
+				},
+				[]*rule.Error{},
+			),
+			expectedErr: nil,
+			expectedStatistic: &debug.Statistic{
+				Start:     start,
+				Files:     4,
+				FileSkips: 0,
+				Dirs:      3,
+				DirSkips:  0,
+				RWMutex:   new(sync.RWMutex),
+			},
+			expectedErrors: []*rule.Error{
+				{
+					Path: "too_long.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						new(rule.Length).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "README.md",
+					Ext:  ".md",
+					Rules: []rule.Rule{
+						new(rule.Length).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "src/abcd",
+					Ext:  ".dir",
+					Rules: []rule.Rule{
+						new(rule.Length).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+			},
+		},
+		{
+			description: "portable",
+			filesystem: fstest.MapFS{
+				"index.ts":     &fstest.MapFile{Mode: fs.ModePerm},
+				"my file.ts":   &fstest.MapFile{Mode: fs.ModePerm},
+				"aux.ts":       &fstest.MapFile{Mode: fs.ModePerm},
+				"README.md":    &fstest.MapFile{Mode: fs.ModePerm},
+				"Read Me.md":   &fstest.MapFile{Mode: fs.ModePerm},
+				"src":          &fstest.MapFile{Mode: fs.ModeDir},
+				"src/a:b":      &fstest.MapFile{Mode: fs.ModeDir},
+				"src/nul":      &fstest.MapFile{Mode: fs.ModeDir},
+				"src/my files": &fstest.MapFile{Mode: fs.ModeDir},
+				"src/dist.":    &fstest.MapFile{Mode: fs.ModeDir},
+			},
+			paths: nil,
+			linter: NewLinter(
+				".",
+				&config.Config{
+					Ls: config.Ls{
+						".dir": "portable:windows,trailing",
+						".ts":  "portable",
+						".md":  "portable:posix",
+					},
+					Ignore:  []string{},
+					RWMutex: new(sync.RWMutex),
+				},
+				&debug.Statistic{
+					Start:     start,
+					Files:     0,
+					FileSkips: 0,
+					Dirs:      0,
+					DirSkips:  0,
+					RWMutex:   new(sync.RWMutex),
+				},
+				[]*rule.Error{},
+			),
+			expectedErr: nil,
+			expectedStatistic: &debug.Statistic{
+				Start:     start,
+				Files:     5,
+				FileSkips: 0,
+				Dirs:      6,
+				DirSkips:  0,
+				RWMutex:   new(sync.RWMutex),
+			},
+			expectedErrors: []*rule.Error{
+				{
+					Path: "aux.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						new(rule.Portable).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "my file.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						new(rule.Portable).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "Read Me.md",
+					Ext:  ".md",
+					Rules: []rule.Rule{
+						new(rule.Portable).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "src/a:b",
+					Ext:  ".dir",
+					Rules: []rule.Rule{
+						new(rule.Portable).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "src/dist.",
+					Ext:  ".dir",
+					Rules: []rule.Rule{
+						new(rule.Portable).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "src/nul",
+					Ext:  ".dir",
+					Rules: []rule.Rule{
+						new(rule.Portable).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+			},
+		},
+		{
+			description: "unique casefold",
+			filesystem: fstest.MapFS{
+				"Button.tsx":        &fstest.MapFile{Mode: fs.ModePerm},
+				"button.tsx":        &fstest.MapFile{Mode: fs.ModePerm},
+				"input.tsx":         &fstest.MapFile{Mode: fs.ModePerm},
+				"src":               &fstest.MapFile{Mode: fs.ModeDir},
+				"src/caf\u00e9.ts":  &fstest.MapFile{Mode: fs.ModePerm},
+				"src/Cafe\u0301.ts": &fstest.MapFile{Mode: fs.ModePerm},
+				"src/cafe.ts":       &fstest.MapFile{Mode: fs.ModePerm},
+				"src/Utils":         &fstest.MapFile{Mode: fs.ModeDir},
+				"src/utils.ts":      &fstest.MapFile{Mode: fs.ModePerm},
+				"dist":              &fstest.MapFile{Mode: fs.ModeDir},
+				"dist/A.js":         &fstest.MapFile{Mode: fs.ModePerm},
+				"dist/a.js":         &fstest.MapFile{Mode: fs.ModePerm},
+			},
+			paths: nil,
+			linter: NewLinter(
+				".",
+				&config.Config{
+					Ls: config.Ls{
+						".dir": "unique-casefold",
+					},
+					Ignore:  []string{"dist"},
+					RWMutex: new(sync.RWMutex),
+				},
+				&debug.Statistic{
+					Start:     start,
+					Files:     0,
+					FileSkips: 0,
+					Dirs:      0,
+					DirSkips:  0,
+					RWMutex:   new(sync.RWMutex),
+				},
+				[]*rule.Error{},
+			),
+			expectedErr: nil,
+			expectedStatistic: &debug.Statistic{
+				Start:     start,
+				Files:     7,
+				FileSkips: 0,
+				Dirs:      3,
+				DirSkips:  1,
+				RWMutex:   new(sync.RWMutex),
+			},
+			expectedErrors: []*rule.Error{
+				{
+					Path: ".",
+					Ext:  ".dir",
+					Rules: []rule.Rule{
+						new(rule.UniqueCasefold).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "src",
+					Ext:  ".dir",
+					Rules: []rule.Rule{
+						new(rule.UniqueCasefold).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+			},
+		},
+		{
+			description: "prefix and suffix",
+			filesystem: fstest.MapFS{
+				"hooks":                         &fstest.MapFile{Mode: fs.ModeDir},
+				"hooks/useState.ts":             &fstest.MapFile{Mode: fs.ModePerm},
+				"hooks/state.ts":                &fstest.MapFile{Mode: fs.ModePerm},
+				"hooks/usestate.ts":             &fstest.MapFile{Mode: fs.ModePerm},
+				"controllers":                   &fstest.MapFile{Mode: fs.ModeDir},
+				"controllers/UserController.ts": &fstest.MapFile{Mode: fs.ModePerm},
+				"controllers/User.ts":           &fstest.MapFile{Mode: fs.ModePerm},
+			},
+			paths: nil,
+			linter: NewLinter(
+				".",
+				&config.Config{
+					Ls: config.Ls{
+						"hooks": config.Ls{
+							".ts": "prefix:use+PascalCase",
+						},
+						"controllers": config.Ls{
+							".ts": "suffix:Controller",
+						},
+					},
+					Ignore:  []string{},
+					RWMutex: new(sync.RWMutex),
+				},
+				&debug.Statistic{
+					Start:     start,
+					Files:     0,
+					FileSkips: 0,
+					Dirs:      0,
+					DirSkips:  0,
+					RWMutex:   new(sync.RWMutex),
+				},
+				[]*rule.Error{},
+			),
+			expectedErr: nil,
+			expectedStatistic: &debug.Statistic{
+				Start:     start,
+				Files:     5,
+				FileSkips: 0,
+				Dirs:      3,
+				DirSkips:  0,
+				RWMutex:   new(sync.RWMutex),
+			},
+			expectedErrors: []*rule.Error{
+				{
+					Path: "controllers/User.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						new(rule.Suffix).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "hooks/state.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						new(rule.Prefix).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "hooks/usestate.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						new(rule.Prefix).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+			},
+		},
+		{
+			description: "and expression",
+			filesystem: fstest.MapFS{
+				"my-file.ts":      &fstest.MapFile{Mode: fs.ModePerm},
+				"MyFile.ts":       &fstest.MapFile{Mode: fs.ModePerm},
+				"my-long-file.ts": &fstest.MapFile{Mode: fs.ModePerm},
+				"test.ts":         &fstest.MapFile{Mode: fs.ModePerm},
+				"src":             &fstest.MapFile{Mode: fs.ModeDir},
+				"src/srcDir":      &fstest.MapFile{Mode: fs.ModeDir},
+				"src/src_dir":     &fstest.MapFile{Mode: fs.ModeDir},
+				"src/ab":          &fstest.MapFile{Mode: fs.ModeDir},
+			},
+			paths: nil,
+			linter: NewLinter(
+				".",
+				&config.Config{
+					Ls: config.Ls{
+						".dir": "(kebab-case | camelCase) & length:3-10",
+						".ts":  "kebab-case & length:1-8 & regex:!test",
+					},
+					Ignore:  []string{},
+					RWMutex: new(sync.RWMutex),
+				},
+				&debug.Statistic{
+					Start:     start,
+					Files:     0,
+					FileSkips: 0,
+					Dirs:      0,
+					DirSkips:  0,
+					RWMutex:   new(sync.RWMutex),
+				},
+				[]*rule.Error{},
+			),
+			expectedErr: nil,
+			expectedStatistic: &debug.Statistic{
+				Start:     start,
+				Files:     4,
+				FileSkips: 0,
+				Dirs:      5,
+				DirSkips:  0,
+				RWMutex:   new(sync.RWMutex),
+			},
+			expectedErrors: []*rule.Error{
+				{
+					Path: "my-long-file.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						rule.NewAnd(nil),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "MyFile.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						rule.NewAnd(nil),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "src/ab",
+					Ext:  ".dir",
+					Rules: []rule.Rule{
+						rule.NewAnd(nil),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "src/src_dir",
+					Ext:  ".dir",
+					Rules: []rule.Rule{
+						rule.NewAnd(nil),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "test.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						rule.NewAnd(nil),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+			},
+		},
+		{
+			description: "rule objects",
+			filesystem: fstest.MapFS{
+				"my-file.ts":  &fstest.MapFile{Mode: fs.ModePerm},
+				"useState.ts": &fstest.MapFile{Mode: fs.ModePerm},
+				"MyFile.ts":   &fstest.MapFile{Mode: fs.ModePerm},
+				"a.md":        &fstest.MapFile{Mode: fs.ModePerm},
+			},
+			paths: nil,
+			linter: NewLinter(
+				".",
+				&config.Config{
+					Ls: config.Ls{
+						".ts": []interface{}{
+							"kebab-case",
+							map[string]interface{}{"rule": "prefix", "text": "use", "remainder": "PascalCase", "message": "use kebab-case or a hook name"},
+						},
+						".md": []interface{}{
+							map[string]interface{}{"rule": "exists", "min": 2},
+						},
+					},
+					Ignore:  []string{},
+					RWMutex: new(sync.RWMutex),
+				},
+				&debug.Statistic{
+					Start:     start,
+					Files:     0,
+					FileSkips: 0,
+					Dirs:      0,
+					DirSkips:  0,
+					RWMutex:   new(sync.RWMutex),
+				},
+				[]*rule.Error{},
+			),
+			expectedErr: nil,
+			expectedStatistic: &debug.Statistic{
+				Start:     start,
+				Files:     4,
+				FileSkips: 0,
+				Dirs:      1,
+				DirSkips:  0,
+				RWMutex:   new(sync.RWMutex),
+			},
+			expectedErrors: []*rule.Error{
+				{
+					Path: "",
+					Dir:  true,
+					Ext:  ".md",
+					Rules: []rule.Rule{
+						new(rule.Exists).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+				{
+					Path: "MyFile.ts",
+					Ext:  ".ts",
+					Rules: []rule.Rule{
+						new(rule.KebabCase).Init(),
+						new(rule.Prefix).Init(),
+					},
+					RWMutex: new(sync.RWMutex),
+				},
+			},
+		},
 		{
 			description: "exists",
 			filesystem: fstest.MapFS{
@@ -794,3 +1369,78 @@ func TestLinter_Run(t *testing.T) {
 		i++
 	}
 }
+
+func TestLinter_GetLintedPaths(t *testing.T) {
+	lslintConfig, err := config.Parse(nil, ".ls-lint.yml", []byte("ls:\n  .md: kebab-case\n  src:\n    .dir: kebab-case\n    .ts: kebab-case\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filesystem := fstest.MapFS{
+		"readme.md":           &fstest.MapFile{Mode: fs.ModePerm},
+		".ls-lint.yml":        &fstest.MapFile{Mode: fs.ModePerm},
+		"src/button.ts":       &fstest.MapFile{Mode: fs.ModePerm},
+		"src/NotKebab/use.ts": &fstest.MapFile{Mode: fs.ModePerm},
+		"src/readme.txt":      &fstest.MapFile{Mode: fs.ModePerm},
+	}
+
+	linter := NewLinter(".", lslintConfig, debug.NewStatistic(), make([]*rule.Error, 0))
+	if err = linter.Run(filesystem, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// paths without rules for their extension aren't linted
+	expected := map[string][]string{
+		"":    {"readme.md"},
+		"src": {"src", "src/NotKebab", "src/NotKebab/use.ts", "src/button.ts"},
+	}
+
+	linted := linter.GetLintedPaths()
+	for indexDir := range linted {
+		slices.Sort(linted[indexDir])
+	}
+
+	if !reflect.DeepEqual(linted, expected) {
+		t.Errorf("Test failed with unmatched linted paths - %+v", linted)
+	}
+
+	for _, ruleErr := range linter.GetErrors() {
+		if ruleErr.GetIndexDir() != "src" {
+			t.Errorf("Test failed with unmatched index dir - %s: %s", ruleErr.GetPath(), ruleErr.GetIndexDir())
+		}
+	}
+}
+
+func TestLinter_UniqueCasefoldNameErrors(t *testing.T) {
+	lslintConfig, err := config.Parse(nil, ".ls-lint.yml", []byte("ls:\n  .dir: kebab-case | unique-casefold\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filesystem := fstest.MapFS{
+		"MyDir/a.ts": &fstest.MapFile{Mode: fs.ModePerm},
+		"MyDir/A.ts": &fstest.MapFile{Mode: fs.ModePerm},
+	}
+
+	linter := NewLinter(".", lslintConfig, debug.NewStatistic(), make([]*rule.Error, 0))
+	if err = linter.Run(filesystem, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// the dir name error doesn't contain the unique-casefold rule of the collision error
+	expected := []string{"MyDir:expected my-dir (kebab-case)", "MyDir:unique-casefold (A.ts, a.ts)"}
+	actual := make([]string, 0, len(linter.GetErrors()))
+	for _, ruleErr := range linter.GetErrors() {
+		messages := make([]string, 0, len(ruleErr.GetFailedRules()))
+		for _, errRule := range ruleErr.GetFailedRules() {
+			messages = append(messages, errRule.GetErrorMessage())
+		}
+
+		actual = append(actual, ruleErr.GetPath()+":"+strings.Join(messages, " | "))
+	}
+
+	slices.Sort(actual)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Test failed with unmatched errors - %+v", actual)
+	}
+}
@@ -0,0 +1,516 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/config"
+	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/fix"
+	"github.com/loeffel-io/ls-lint/v2/internal/linter"
+	"github.com/loeffel-io/ls-lint/v2/internal/output"
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const (
+	source     = "ls-lint"
+	configFile = ".ls-lint.yml"
+	dir        = ".dir"
+)
+
+// LoadConfigFunc loads the config of the workspace root
+type LoadConfigFunc func(root string) (*config.Config, error)
+
+// Server is a language server over stdio
+// it publishes diagnostics for documents with names violating the config and for invalid config files
+type Server struct {
+	reader      *bufio.Reader
+	writer      io.Writer
+	root        string
+	loadConfig  LoadConfigFunc
+	version     string
+	config      *config.Config
+	incremental *linter.Incremental
+	documents   map[string]struct{}
+}
+
+func NewServer(reader io.Reader, writer io.Writer, root string, loadConfig LoadConfigFunc, version string) *Server {
+	return &Server{
+		reader:     bufio.NewReader(reader),
+		writer:     writer,
+		root:       root,
+		loadConfig: loadConfig,
+		version:    version,
+		documents:  make(map[string]struct{}),
+	}
+}
+
+// Serve handles messages until the exit notification or the end of the input
+func (server *Server) Serve() error {
+	for {
+		msg, err := readMessage(server.reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			var respErr *responseError
+			if errors.As(err, &respErr) {
+				if err = writeMessage(server.writer, &message{JSONRPC: jsonrpcVersion, Error: respErr}); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		result, err := server.handle(msg)
+
+		// notifications have no response
+		if msg.ID == nil {
+			if err != nil {
+				if err = server.log(err); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if err != nil {
+			respErr := &responseError{Code: codeInternalError, Message: err.Error()}
+			errors.As(err, &respErr)
+
+			if err = writeMessage(server.writer, &message{JSONRPC: jsonrpcVersion, ID: msg.ID, Error: respErr}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err = writeMessage(server.writer, &response{JSONRPC: jsonrpcVersion, ID: msg.ID, Result: result}); err != nil {
+			return err
+		}
+	}
+}
+
+func (server *Server) handle(msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		var params initializeParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+
+		rootURI := params.RootURI
+		if rootURI == "" && len(params.WorkspaceFolders) > 0 {
+			rootURI = params.WorkspaceFolders[0].URI
+		}
+
+		if rootURI != "" {
+			root, err := uriToPath(rootURI)
+			if err != nil {
+				return nil, err
+			}
+
+			server.root = root
+		}
+
+		filters := []fileOperationFilter{{Pattern: fileOperationPattern{Glob: "**"}}}
+		return &initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncOptions{OpenClose: true, Change: textDocumentSyncFull, Save: true},
+				CodeActionProvider: true,
+				Workspace: workspaceCapabilities{FileOperations: fileOperations{
+					DidCreate: fileOperationRegistration{Filters: filters},
+					DidRename: fileOperationRegistration{Filters: filters},
+					DidDelete: fileOperationRegistration{Filters: filters},
+				}},
+			},
+			ServerInfo: serverInfo{Name: source, Version: server.version},
+		}, nil
+	case "initialized":
+		return nil, server.load()
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+
+		server.documents[params.TextDocument.URI] = struct{}{}
+		if isConfig(params.TextDocument.URI) {
+			return nil, server.publishConfig(params.TextDocument.URI, params.TextDocument.Text)
+		}
+
+		return nil, server.update([]string{params.TextDocument.URI}, nil)
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+
+		if !isConfig(params.TextDocument.URI) || len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+
+		return nil, server.publishConfig(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+	case "textDocument/didSave":
+		var params didSaveTextDocumentParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+
+		if !isConfig(params.TextDocument.URI) {
+			return nil, nil
+		}
+
+		if err := server.load(); err != nil {
+			return nil, err
+		}
+
+		return nil, server.update(nil, nil)
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+
+		delete(server.documents, params.TextDocument.URI)
+		return nil, server.publish(params.TextDocument.URI, make([]diagnostic, 0))
+	case "workspace/didCreateFiles", "workspace/didDeleteFiles":
+		var params createFilesParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+
+		uris := make([]string, 0, len(params.Files))
+		for _, file := range params.Files {
+			uris = append(uris, file.URI)
+		}
+
+		return nil, server.update(uris, nil)
+	case "workspace/didRenameFiles":
+		var params renameFilesParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+
+		uris := make([]string, 0, len(params.Files))
+		removed := make([]string, 0, len(params.Files))
+		for _, file := range params.Files {
+			uris = append(uris, file.NewURI)
+			removed = append(removed, file.OldURI)
+		}
+
+		return nil, server.update(uris, removed)
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := decode(msg.Params, &params); err != nil {
+			return nil, err
+		}
+
+		return server.codeActions(params.TextDocument.URI)
+	}
+
+	// unknown notifications (e.g. $/cancelRequest) are ignored
+	if msg.ID == nil {
+		return nil, nil
+	}
+
+	return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %s not found", msg.Method)}
+}
+
+// load lints the workspace with the loaded config
+func (server *Server) load() (err error) {
+	var lslintConfig *config.Config
+	if lslintConfig, err = server.loadConfig(server.root); err != nil {
+		return err
+	}
+
+	if server.incremental != nil {
+		if _, _, err = server.incremental.Reload(lslintConfig); err != nil {
+			return err
+		}
+
+		server.config = lslintConfig
+		return nil
+	}
+
+	incremental := linter.NewIncremental(
+		linter.NewLinter(".", lslintConfig, debug.NewStatistic(), make([]*rule.Error, 0)),
+		os.DirFS(server.root),
+	)
+
+	if err = incremental.Init(); err != nil {
+		return err
+	}
+
+	server.config = lslintConfig
+	server.incremental = incremental
+	return nil
+}
+
+// update re-lints the changed documents and publishes the diagnostics of them and all open documents
+func (server *Server) update(uris []string, removed []string) error {
+	if server.incremental == nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(uris)+len(removed))
+	for _, uri := range append(removed, uris...) {
+		if p, ok := server.relPath(uri); ok {
+			paths = append(paths, p)
+		}
+	}
+
+	if len(paths) > 0 {
+		if _, _, err := server.incremental.Update(paths); err != nil {
+			return err
+		}
+	}
+
+	publish := make(map[string]struct{}, len(server.documents)+len(uris))
+	for uri := range server.documents {
+		publish[uri] = struct{}{}
+	}
+
+	for _, uri := range uris {
+		publish[uri] = struct{}{}
+	}
+
+	for uri := range publish {
+		if isConfig(uri) {
+			continue
+		}
+
+		if err := server.publish(uri, server.diagnostics(uri)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// errors returns the errors of the document path and of its dir
+// unique-casefold errors of the dir are only returned for the colliding documents
+func (server *Server) errors(uri string) []*rule.Error {
+	p, ok := server.relPath(uri)
+	if !ok || server.incremental == nil {
+		return nil
+	}
+
+	ruleErrors := make([]*rule.Error, 0)
+	for _, ruleErr := range server.incremental.GetErrors() {
+		if ruleErr.IsDir() {
+			continue // exists errors
+		}
+
+		if ruleErr.GetPath() == p {
+			ruleErrors = append(ruleErrors, ruleErr)
+			continue
+		}
+
+		if ruleErr.GetExt() != dir || path.Dir(p) != ruleErr.GetPath() {
+			continue
+		}
+
+		if collisions, ok := getCollisions(ruleErr); ok && !slices.Contains(collisions, path.Base(p)) {
+			continue
+		}
+
+		ruleErrors = append(ruleErrors, ruleErr)
+	}
+
+	return ruleErrors
+}
+
+// getCollisions returns the colliding names of unique-casefold errors
+func getCollisions(ruleErr *rule.Error) ([]string, bool) {
+	for _, errRule := range ruleErr.GetFailedRules() {
+		if uniqueCasefold, ok := rule.Unwrap(errRule).(*rule.UniqueCasefold); ok {
+			return uniqueCasefold.GetParameters(), true
+		}
+	}
+
+	return nil, false
+}
+
+func (server *Server) diagnostics(uri string) []diagnostic {
+	diagnostics := make([]diagnostic, 0)
+	for _, ruleErr := range server.errors(uri) {
+		var description *codeDescription
+		if url := ruleErr.GetURL(); url != "" {
+			description = &codeDescription{Href: url}
+		}
+
+		diagnostics = append(diagnostics, diagnostic{
+			Severity:        diagnosticSeverity(ruleErr.GetSeverity()),
+			Source:          source,
+			Message:         output.Message(ruleErr),
+			CodeDescription: description,
+		})
+	}
+
+	return diagnostics
+}
+
+// diagnosticSeverity returns the diagnostic severity of the rule severity
+func diagnosticSeverity(severity string) int {
+	switch severity {
+	case rule.SeverityWarning:
+		return severityWarning
+	case rule.SeverityInfo:
+		return severityInformation
+	default:
+		return severityError
+	}
+}
+
+func (server *Server) publishConfig(uri string, text string) error {
+	validator := server.config
+	if validator == nil {
+		validator = config.NewConfig(nil, nil)
+	}
+
+	diagnostics := make([]diagnostic, 0)
+	for _, problem := range validator.Validate([]byte(text)) {
+		start := position{Line: problem.Line - 1, Character: problem.Column - 1}
+		end := position{Line: start.Line, Character: start.Character + problem.Length}
+
+		diagnostics = append(diagnostics, diagnostic{
+			Range:    lspRange{Start: start, End: end},
+			Severity: severityError,
+			Source:   source,
+			Message:  problem.Message,
+		})
+	}
+
+	return server.publish(uri, diagnostics)
+}
+
+func (server *Server) publish(uri string, diagnostics []diagnostic) error {
+	return server.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
+
+// codeActions offers the renames of the document and its dir
+func (server *Server) codeActions(uri string) ([]codeAction, error) {
+	actions := make([]codeAction, 0)
+
+	ruleErrors := server.errors(uri)
+	if len(ruleErrors) == 0 {
+		return actions, nil
+	}
+
+	renames, _, err := fix.Plan(os.DirFS(server.root), ruleErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rename := range renames {
+		actions = append(actions, codeAction{
+			Title: fmt.Sprintf("Rename %s to %s (%s)", path.Base(rename.From), path.Base(rename.To), rename.Rule),
+			Kind:  codeActionQuickFix,
+			Edit: workspaceEdit{DocumentChanges: []renameFile{{
+				Kind:   "rename",
+				OldURI: pathToURI(filepath.Join(server.root, filepath.FromSlash(rename.From))),
+				NewURI: pathToURI(filepath.Join(server.root, filepath.FromSlash(rename.To))),
+			}}},
+		})
+	}
+
+	return actions, nil
+}
+
+func (server *Server) notify(method string, params any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return writeMessage(server.writer, &message{JSONRPC: jsonrpcVersion, Method: method, Params: data})
+}
+
+// log reports errors of notifications as error log messages
+func (server *Server) log(err error) error {
+	return server.notify("window/logMessage", &logMessageParams{Type: severityError, Message: err.Error()})
+}
+
+// relPath returns the slash separated path of the uri relative to the root
+func (server *Server) relPath(uri string) (string, bool) {
+	p, err := uriToPath(uri)
+	if err != nil {
+		return "", false
+	}
+
+	root, err := filepath.Abs(server.root)
+	if err != nil {
+		return "", false
+	}
+
+	if p, err = filepath.Rel(root, p); err != nil || p == "." || strings.HasPrefix(p, "..") {
+		return "", false
+	}
+
+	return filepath.ToSlash(p), true
+}
+
+func decode(params json.RawMessage, v any) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
+
+func isConfig(uri string) bool {
+	return path.Base(uri) == configFile
+}
+
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported uri scheme %s", u.Scheme)
+	}
+
+	p := u.Path
+	// windows drive letters like /C:/path
+	if len(p) > 2 && p[0] == '/' && p[2] == ':' {
+		p = p[1:]
+	}
+
+	return filepath.FromSlash(p), nil
+}
+
+func pathToURI(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		p = abs
+	}
+
+	p = filepath.ToSlash(p)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
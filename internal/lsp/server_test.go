@@ -0,0 +1,310 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/config"
+)
+
+func TestServer_Serve(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "NotKebab.ts"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loadConfig := func(_ string) (*config.Config, error) {
+		return &config.Config{
+			Ls:      config.Ls{".ts": "kebab-case"},
+			Ignore:  []string{},
+			RWMutex: new(sync.RWMutex),
+		}, nil
+	}
+
+	fileURI := pathToURI(filepath.Join(root, "NotKebab.ts"))
+	configURI := pathToURI(filepath.Join(root, ".ls-lint.yml"))
+
+	var input bytes.Buffer
+	for _, msg := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"rootUri":"` + pathToURI(root) + `"}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"` + fileURI + `","text":""}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/codeAction","params":{"textDocument":{"uri":"` + fileURI + `"}}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"` + configURI + `","text":"ls:\n  .ts: kebabcase | regex:[a-z\n"}}}`,
+		`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":2}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"unknown"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	} {
+		if err := writeMessage(&input, json.RawMessage(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var output bytes.Buffer
+	if err := NewServer(&input, &output, ".", loadConfig, "test").Serve(); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := make([]*message, 0)
+	reader := bufio.NewReader(&output)
+	for {
+		msg, err := readMessage(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	if len(messages) != 6 {
+		t.Fatalf("unmatched messages count - %d", len(messages))
+	}
+
+	var diagnostics publishDiagnosticsParams
+	if err := json.Unmarshal(messages[1].Params, &diagnostics); err != nil {
+		t.Fatal(err)
+	}
+
+	if diagnostics.URI != fileURI || len(diagnostics.Diagnostics) != 1 || diagnostics.Diagnostics[0].Message != "NotKebab.ts failed for `.ts` rules: expected not-kebab.ts (kebab-case)" {
+		t.Errorf("unmatched diagnostics - %+v", diagnostics)
+	}
+
+	actions := make([]codeAction, 0)
+	if err := remarshal(messages[2].Result, &actions); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedActions := []codeAction{{
+		Title: "Rename NotKebab.ts to not-kebab.ts (kebabcase)",
+		Kind:  codeActionQuickFix,
+		Edit: workspaceEdit{DocumentChanges: []renameFile{{
+			Kind:   "rename",
+			OldURI: fileURI,
+			NewURI: pathToURI(filepath.Join(root, "not-kebab.ts")),
+		}}},
+	}}
+	if !reflect.DeepEqual(actions, expectedActions) {
+		t.Errorf("unmatched code actions - %+v", actions)
+	}
+
+	if err := json.Unmarshal(messages[3].Params, &diagnostics); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDiagnostics := []diagnostic{{
+		Range:    lspRange{Start: position{Line: 1, Character: 19}, End: position{Line: 1, Character: 29}},
+		Severity: severityError,
+		Source:   source,
+		Message:  "rule regex failed with invalid regex pattern: error parsing regexp: missing closing ]: `[a-z`",
+	}}
+	if diagnostics.URI != configURI || !reflect.DeepEqual(diagnostics.Diagnostics, expectedDiagnostics) {
+		t.Errorf("unmatched config diagnostics - %+v", diagnostics)
+	}
+
+	if messages[4].Error == nil || messages[4].Error.Code != codeMethodNotFound {
+		t.Errorf("unmatched unknown method response - %+v", messages[4])
+	}
+
+	if messages[5].Error != nil || string(*messages[5].ID) != "4" {
+		t.Errorf("unmatched shutdown response - %+v", messages[5])
+	}
+}
+
+func remarshal(value any, v any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func TestServer_CodeActions(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".vscode"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ".vscode", "settings.json"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loadConfig := func(_ string) (*config.Config, error) {
+		return &config.Config{
+			Ls:      config.Ls{".dir": "kebab-case", ".json": "kebab-case"},
+			Ignore:  []string{},
+			RWMutex: new(sync.RWMutex),
+		}, nil
+	}
+
+	fileURI := pathToURI(filepath.Join(root, ".vscode", "settings.json"))
+
+	var input bytes.Buffer
+	for _, msg := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"rootUri":"` + pathToURI(root) + `"}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"` + fileURI + `","text":""}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/codeAction","params":{"textDocument":{"uri":"` + fileURI + `"}}}`,
+	} {
+		if err := writeMessage(&input, json.RawMessage(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var output bytes.Buffer
+	if err := NewServer(&input, &output, ".", loadConfig, "test").Serve(); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := make([]*message, 0)
+	reader := bufio.NewReader(&output)
+	for {
+		msg, err := readMessage(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	if len(messages) != 3 {
+		t.Fatalf("unmatched messages count - %d", len(messages))
+	}
+
+	var diagnostics publishDiagnosticsParams
+	if err := json.Unmarshal(messages[1].Params, &diagnostics); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diagnostics.Diagnostics) != 1 {
+		t.Errorf("unmatched diagnostics - %+v", diagnostics)
+	}
+
+	// hidden dirs are never renamed
+	actions := make([]codeAction, 0)
+	if err := remarshal(messages[2].Result, &actions); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actions) != 0 {
+		t.Errorf("unmatched code actions - %+v", actions)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"MyDir/a.ts", "MyDir/nested/b.ts", "Button.ts", "button.ts", "other.ts"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(root, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(root, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loadConfig := func(_ string) (*config.Config, error) {
+		return &config.Config{
+			Ls:      config.Ls{".dir": []any{"kebab-case", "unique-casefold"}},
+			Ignore:  []string{},
+			RWMutex: new(sync.RWMutex),
+		}, nil
+	}
+
+	server := NewServer(nil, nil, root, loadConfig, "test")
+	if err := server.load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// dir errors are only returned for the documents of the dir and collisions only for the colliding documents
+	tests := []*struct {
+		path     string
+		expected int
+	}{
+		{path: "MyDir/a.ts", expected: 1},
+		{path: "MyDir/nested/b.ts", expected: 0},
+		{path: "Button.ts", expected: 1},
+		{path: "button.ts", expected: 1},
+		{path: "other.ts", expected: 0},
+	}
+
+	for i, test := range tests {
+		if res := server.errors(pathToURI(filepath.Join(root, filepath.FromSlash(test.path)))); len(res) != test.expected {
+			t.Errorf("Test %d failed with unmatched errors - %d", i, len(res))
+		}
+	}
+}
+
+func TestReadMessage(t *testing.T) {
+	tests := []*struct {
+		input string
+		valid bool
+	}{
+		{input: "Content-Length: 2\r\n\r\n{}", valid: true},
+		{input: "Content-Length: abc\r\n\r\n{}", valid: false},
+		{input: "Content-Length: 0\r\n\r\n", valid: false},
+		{input: "Content-Length: -1\r\n\r\n{}", valid: false},
+		{input: "Content-Length: 9999999999\r\n\r\n{}", valid: false},
+	}
+
+	for i, test := range tests {
+		if _, err := readMessage(bufio.NewReader(bytes.NewBufferString(test.input))); (err == nil) != test.valid {
+			t.Errorf("Test %d failed with unmatched return value - %v", i, err)
+		}
+	}
+}
+
+func TestServer_Diagnostics(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "NotKebab.ts"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loadConfig := func(root string) (*config.Config, error) {
+		return &config.Config{
+			Ls: config.Ls{".ts": config.Ls{
+				"rules":   "kebab-case",
+				"message": "files are kebab-case",
+				"url":     "https://example.com/naming",
+			}},
+			Ignore:  []string{},
+			RWMutex: new(sync.RWMutex),
+		}, nil
+	}
+
+	server := NewServer(nil, nil, root, loadConfig, "test")
+	if err := server.load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// diagnostics share the message of the outputs and link the url
+	diagnostics := server.diagnostics(pathToURI(filepath.Join(root, "NotKebab.ts")))
+	if len(diagnostics) != 1 {
+		t.Fatalf("unmatched diagnostics - %+v", diagnostics)
+	}
+
+	if expected := "NotKebab.ts failed for `.ts` rules: expected not-kebab.ts (kebab-case) - files are kebab-case (https://example.com/naming)"; diagnostics[0].Message != expected {
+		t.Errorf("unmatched message - %s", diagnostics[0].Message)
+	}
+
+	if diagnostics[0].CodeDescription == nil || diagnostics[0].CodeDescription.Href != "https://example.com/naming" {
+		t.Errorf("unmatched code description - %+v", diagnostics[0].CodeDescription)
+	}
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const checkstyleVersion = "4.3"
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Checkstyle writes the errors as Checkstyle XML report grouped by path
+// every failed rule is reported as its own error on the first line
+func Checkstyle(writer io.Writer, ruleErrors []*rule.Error) (err error) {
+	files := make(map[string][]checkstyleError)
+	for _, ruleErr := range ruleErrors {
+		var suffix string
+		if docs := getDocs(ruleErr); docs != "" {
+			suffix = " - " + docs
+		}
+
+		path := getPath(ruleErr)
+		for _, errRule := range ruleErr.GetFailedRules() {
+			files[path] = append(files[path], checkstyleError{
+				Line:     1,
+				Severity: checkstyleSeverity(rule.SeverityOf(errRule)),
+				Message:  errRule.GetErrorMessage() + suffix,
+				Source:   "ls-lint." + errRule.GetName(),
+			})
+		}
+	}
+
+	report := checkstyleReport{
+		Version: checkstyleVersion,
+		Files:   make([]checkstyleFile, 0, len(files)),
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(files)) {
+		report.Files = append(report.Files, checkstyleFile{Name: path, Errors: files[path]})
+	}
+
+	var xmlStr []byte
+	if xmlStr, err = xml.MarshalIndent(report, "", "  "); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(writer, "%s%s\n", xml.Header, xmlStr)
+	return err
+}
+
+// checkstyleSeverity returns the checkstyle severity of the severity
+func checkstyleSeverity(severity string) string {
+	switch severity {
+	case rule.SeverityWarning:
+		return "warning"
+	case rule.SeverityInfo:
+		return "info"
+	default:
+		return "error"
+	}
+}
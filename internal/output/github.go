@@ -0,0 +1,51 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// GitHub writes the errors as GitHub Actions workflow commands which annotate the paths
+// annotation paths are prefixed with the workdir because they are resolved from the repository root
+func GitHub(writer io.Writer, ruleErrors []*rule.Error, workdir string) (err error) {
+	if workdir, err = getRelativeWorkdir(workdir); err != nil {
+		return err
+	}
+
+	for _, ruleErr := range ruleErrors {
+		if len(ruleErr.GetFailedRules()) == 0 {
+			continue
+		}
+
+		if _, err = fmt.Fprintf(writer, "::%s file=%s,title=%s::%s\n",
+			githubCommand(ruleErr.GetSeverity()),
+			githubPropertyEscaper.Replace(getWorkdirPath(ruleErr, workdir)),
+			githubPropertyEscaper.Replace(fmt.Sprintf("ls-lint (%s)", ruleErr.GetExt())),
+			githubDataEscaper.Replace(Message(ruleErr)),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// githubCommand returns the workflow command of the severity
+func githubCommand(severity string) string {
+	switch severity {
+	case rule.SeverityWarning:
+		return "warning"
+	case rule.SeverityInfo:
+		return "notice"
+	default:
+		return "error"
+	}
+}
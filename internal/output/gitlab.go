@@ -0,0 +1,92 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+// GitLab writes the errors as GitLab Code Quality report
+// location paths are prefixed with the workdir because they are resolved from the repository root
+// fingerprints are built from the path, extension and failed rule names to keep them stable across runs
+func GitLab(writer io.Writer, ruleErrors []*rule.Error, workdir string) (err error) {
+	if workdir, err = getRelativeWorkdir(workdir); err != nil {
+		return err
+	}
+
+	issues := make([]gitlabIssue, 0, len(ruleErrors))
+	occurrences := make(map[string]int, len(ruleErrors))
+	for _, ruleErr := range ruleErrors {
+		rules := ruleErr.GetFailedRules()
+		if len(rules) == 0 {
+			continue
+		}
+
+		ruleNames := make([]string, 0, len(rules))
+		for _, errRule := range rules {
+			ruleNames = append(ruleNames, errRule.GetName())
+		}
+		slices.Sort(ruleNames)
+
+		path := getWorkdirPath(ruleErr, workdir)
+
+		// multiple errors of the same path and rules (e.g. unique-casefold collisions) are numbered
+		key := strings.Join(append([]string{path, ruleErr.GetExt()}, ruleNames...), "\x00")
+		occurrences[key]++
+
+		fingerprint := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", key, occurrences[key])))
+		issues = append(issues, gitlabIssue{
+			Description: Message(ruleErr),
+			CheckName:   "ls-lint." + rules[0].GetName(),
+			Fingerprint: hex.EncodeToString(fingerprint[:]),
+			Severity:    gitlabSeverity(ruleErr.GetSeverity()),
+			Location: gitlabLocation{
+				Path:  path,
+				Lines: gitlabLines{Begin: 1},
+			},
+		})
+	}
+
+	var jsonStr []byte
+	if jsonStr, err = json.MarshalIndent(issues, "", "  "); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(writer, string(jsonStr))
+	return err
+}
+
+// gitlabSeverity returns the code quality severity of the severity
+func gitlabSeverity(severity string) string {
+	switch severity {
+	case rule.SeverityWarning:
+		return "minor"
+	case rule.SeverityInfo:
+		return "info"
+	default:
+		return "major"
+	}
+}
@@ -0,0 +1,37 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+func JSON(writer io.Writer, ruleErrors []*rule.Error) (err error) {
+	errIndex := make(map[string]map[string][]string, len(ruleErrors))
+	for _, ruleErr := range ruleErrors {
+		path := getPath(ruleErr)
+
+		if _, ok := errIndex[path]; !ok {
+			errIndex[path] = make(map[string][]string)
+		}
+
+		prefix, suffix := getSeverityPrefix(ruleErr), ""
+		if docs := getDocs(ruleErr); docs != "" {
+			suffix = " - " + docs
+		}
+
+		for _, errRule := range ruleErr.GetFailedRules() {
+			errIndex[path][ruleErr.GetExt()] = append(errIndex[path][ruleErr.GetExt()], prefix+errRule.GetErrorMessage()+suffix)
+		}
+	}
+
+	var jsonStr []byte
+	if jsonStr, err = json.Marshal(errIndex); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(writer, string(jsonStr))
+	return err
+}
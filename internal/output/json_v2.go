@@ -0,0 +1,124 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const jsonV2Version = 2
+
+type jsonV2Report struct {
+	Version int           `json:"version"`
+	Errors  []jsonV2Error `json:"errors"`
+	Summary jsonV2Summary `json:"summary"`
+}
+
+type jsonV2Error struct {
+	Path         string       `json:"path"`
+	Kind         string       `json:"kind"`
+	ExtensionKey string       `json:"extension_key"`
+	ConfigDir    string       `json:"config_dir"`
+	Severity     string       `json:"severity"`
+	Help         string       `json:"help,omitempty"`
+	URL          string       `json:"url,omitempty"`
+	Rules        []jsonV2Rule `json:"rules"`
+}
+
+type jsonV2Rule struct {
+	Name    string   `json:"name"`
+	Params  []string `json:"params"`
+	Message string   `json:"message"`
+	Found   *uint16  `json:"found,omitempty"`
+}
+
+type jsonV2Summary struct {
+	Files     int64 `json:"files"`
+	FileSkips int64 `json:"file_skips"`
+	Dirs      int64 `json:"dirs"`
+	DirSkips  int64 `json:"dir_skips"`
+	Errors    int   `json:"errors"`
+}
+
+// JSONv2 writes the errors as versioned JSON report with the failed rules of every error and a summary of the run
+// exists rules contain the number of found files or directories
+func JSONv2(writer io.Writer, ruleErrors []*rule.Error, statistic *debug.Statistic) (err error) {
+	report := jsonV2Report{
+		Version: jsonV2Version,
+		Errors:  make([]jsonV2Error, 0, len(ruleErrors)),
+	}
+
+	for _, ruleErr := range ruleErrors {
+		rules := ruleErr.GetFailedRules()
+		if len(rules) == 0 {
+			continue
+		}
+
+		jsonErr := jsonV2Error{
+			Path:         getPath(ruleErr),
+			Kind:         jsonV2Kind(ruleErr),
+			ExtensionKey: ruleErr.GetExt(),
+			ConfigDir:    ruleErr.GetIndexDir(),
+			Severity:     ruleErr.GetSeverity(),
+			Help:         ruleErr.GetHelp(),
+			URL:          ruleErr.GetURL(),
+			Rules:        make([]jsonV2Rule, 0, len(rules)),
+		}
+
+		if jsonErr.ConfigDir == "" {
+			jsonErr.ConfigDir = "."
+		}
+
+		for _, errRule := range rules {
+			params := errRule.GetParameters()
+			if params == nil {
+				params = make([]string, 0)
+			}
+
+			jsonRule := jsonV2Rule{
+				Name:    errRule.GetName(),
+				Params:  params,
+				Message: errRule.GetErrorMessage(),
+			}
+
+			if exists, ok := rule.Unwrap(errRule).(*rule.Exists); ok {
+				found := exists.GetCount()
+				jsonRule.Found = &found
+			}
+
+			jsonErr.Rules = append(jsonErr.Rules, jsonRule)
+		}
+
+		report.Errors = append(report.Errors, jsonErr)
+	}
+
+	statistic.RLock()
+	report.Summary = jsonV2Summary{
+		Files:     statistic.Files,
+		FileSkips: statistic.FileSkips,
+		Dirs:      statistic.Dirs,
+		DirSkips:  statistic.DirSkips,
+		Errors:    len(report.Errors),
+	}
+	statistic.RUnlock()
+
+	var jsonStr []byte
+	if jsonStr, err = json.Marshal(report); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(writer, string(jsonStr))
+	return err
+}
+
+// jsonV2Kind returns dir for errors of directories and exists errors of rule index dirs and file otherwise
+func jsonV2Kind(ruleErr *rule.Error) string {
+	if ruleErr.IsDir() || ruleErr.GetExt() == ".dir" {
+		return "dir"
+	}
+
+	return "file"
+}
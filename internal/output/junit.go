@@ -0,0 +1,126 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+type junitTestsuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Testsuites []junitTestsuite `xml:"testsuite"`
+}
+
+type junitTestsuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Testcases []junitTestcase `xml:"testcase"`
+}
+
+type junitTestcase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnit writes the errors as JUnit XML report with a testsuite per dir of the rule index
+// linted paths without errors are added as passing testcases - nil skips passing testcases
+func JUnit(writer io.Writer, ruleErrors []*rule.Error, linted map[string][]string) (err error) {
+	failures := make(map[string][]*rule.Error)
+	failed := make(map[string]bool, len(ruleErrors))
+	for _, ruleErr := range ruleErrors {
+		if len(ruleErr.GetFailedRules()) == 0 {
+			continue
+		}
+
+		failures[ruleErr.GetIndexDir()] = append(failures[ruleErr.GetIndexDir()], ruleErr)
+		failed[ruleErr.GetPath()] = true
+	}
+
+	indexDirs := slices.Collect(maps.Keys(failures))
+	for indexDir := range linted {
+		if !slices.Contains(indexDirs, indexDir) {
+			indexDirs = append(indexDirs, indexDir)
+		}
+	}
+	slices.Sort(indexDirs)
+
+	report := junitTestsuites{
+		Name:       "ls-lint",
+		Testsuites: make([]junitTestsuite, 0, len(indexDirs)),
+	}
+
+	for _, indexDir := range indexDirs {
+		suite := junitTestsuite{
+			Name:      junitSuiteName(indexDir),
+			Testcases: make([]junitTestcase, 0, len(failures[indexDir])+len(linted[indexDir])),
+		}
+
+		for _, ruleErr := range failures[indexDir] {
+			ruleMessages := make([]string, 0, len(ruleErr.GetFailedRules()))
+			for _, errRule := range ruleErr.GetFailedRules() {
+				ruleMessages = append(ruleMessages, errRule.GetErrorMessage())
+			}
+
+			suite.Testcases = append(suite.Testcases, junitTestcase{
+				Name:      getPath(ruleErr),
+				Classname: suite.Name,
+				Failure: &junitFailure{
+					Message: Message(ruleErr),
+					Type:    ruleErr.GetSeverity(),
+					Text:    strings.Join(ruleMessages, "\n"),
+				},
+			})
+		}
+
+		suite.Failures = len(suite.Testcases)
+
+		for _, path := range slices.Sorted(slices.Values(linted[indexDir])) {
+			if failed[path] {
+				continue
+			}
+
+			suite.Testcases = append(suite.Testcases, junitTestcase{
+				Name:      path,
+				Classname: suite.Name,
+			})
+		}
+
+		suite.Tests = len(suite.Testcases)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Testsuites = append(report.Testsuites, suite)
+	}
+
+	var xmlStr []byte
+	if xmlStr, err = xml.MarshalIndent(report, "", "  "); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(writer, "%s%s\n", xml.Header, xmlStr)
+	return err
+}
+
+// junitSuiteName returns the name of the testsuite of the dir - the root dir is mapped to .
+func junitSuiteName(indexDir string) string {
+	if indexDir == "" {
+		return "."
+	}
+
+	return indexDir
+}
@@ -0,0 +1,112 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+// getPath returns the error path - empty paths are mapped to the root dir
+func getPath(ruleErr *rule.Error) string {
+	if path := ruleErr.GetPath(); path != "" {
+		return path
+	}
+
+	return "."
+}
+
+// getWorkdirPath returns the error path prefixed with the workdir
+// reports which are resolved from the repository root (e.g. annotations) need paths which don't depend on the workdir
+func getWorkdirPath(ruleErr *rule.Error, workdir string) string {
+	if workdir == "" {
+		return getPath(ruleErr)
+	}
+
+	return path.Join(filepath.ToSlash(filepath.Clean(workdir)), getPath(ruleErr))
+}
+
+// getRelativeWorkdir returns the workdir relative to the current working directory (e.g. the repository root in CI)
+// absolute workdirs are resolved to relative paths - workdirs outside the current working directory are rejected
+func getRelativeWorkdir(workdir string) (string, error) {
+	if workdir == "" {
+		return "", nil
+	}
+
+	abs, err := filepath.Abs(workdir)
+	if err != nil {
+		return "", err
+	}
+
+	var cwd string
+	if cwd, err = os.Getwd(); err != nil {
+		return "", err
+	}
+
+	var rel string
+	if rel, err = filepath.Rel(cwd, abs); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("workdir %s is outside of the current working directory %s", workdir, cwd)
+	}
+
+	return rel, nil
+}
+
+// Message returns the message of the error with the help message and url of the rule set
+func Message(ruleErr *rule.Error) string {
+	var ruleMessages []string
+	for _, errRule := range ruleErr.GetFailedRules() {
+		ruleMessages = append(ruleMessages, errRule.GetErrorMessage())
+	}
+
+	message := fmt.Sprintf("%s failed for `%s` rules: %s", getPath(ruleErr), ruleErr.GetExt(), strings.Join(ruleMessages, " | "))
+	if docs := getDocs(ruleErr); docs != "" {
+		return message + " - " + docs
+	}
+
+	return message
+}
+
+// getDocs returns the help message and url of the rule set of the error
+func getDocs(ruleErr *rule.Error) string {
+	help, url := ruleErr.GetHelp(), ruleErr.GetURL()
+	switch {
+	case help != "" && url != "":
+		return fmt.Sprintf("%s (%s)", help, url)
+	case url != "":
+		return url
+	default:
+		return help
+	}
+}
+
+// getSeverityPrefix returns the prefix of messages of errors with a lower severity than error
+func getSeverityPrefix(ruleErr *rule.Error) string {
+	if severity := ruleErr.GetSeverity(); severity != rule.SeverityError {
+		return severity + ": "
+	}
+
+	return ""
+}
+
+// ExitCode returns 1 if the errors contain an error severity or more warnings than the max warnings
+// a negative max warnings value allows any number of warnings
+func ExitCode(ruleErrors []*rule.Error, maxWarnings int) int {
+	var warnings int
+	for _, ruleErr := range ruleErrors {
+		switch ruleErr.GetSeverity() {
+		case rule.SeverityError:
+			return 1
+		case rule.SeverityWarning:
+			warnings++
+		}
+	}
+
+	if maxWarnings >= 0 && warnings > maxWarnings {
+		return 1
+	}
+
+	return 0
+}
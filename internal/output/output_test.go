@@ -0,0 +1,474 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+func getErrors() []*rule.Error {
+	exists := new(rule.Exists).Init()
+	if err := exists.SetParameters([]string{"1"}); err != nil {
+		panic(err)
+	}
+
+	return []*rule.Error{
+		{
+			Path:    "src/Not Kebab.ts",
+			Ext:     ".ts",
+			Rules:   []rule.Rule{rule.RulesIndex["kebabcase"], exists},
+			RWMutex: new(sync.RWMutex),
+		},
+		{
+			Path:    "",
+			Dir:     true,
+			Ext:     ".png",
+			Rules:   []rule.Rule{exists},
+			RWMutex: new(sync.RWMutex),
+		},
+	}
+}
+
+func TestText(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Text(&buffer, getErrors()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "src/Not Kebab.ts failed for `.ts` rules: kebabcase\n. failed for `.png` rules: exists:1 (found 0)\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched text output - %s", buffer.String())
+	}
+}
+
+func TestDiff(t *testing.T) {
+	var buffer bytes.Buffer
+	ruleErrors := getErrors()
+	if err := Diff(&buffer, ruleErrors[:1], ruleErrors[1:]); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "+ src/Not Kebab.ts failed for `.ts` rules: kebabcase\n- . failed for `.png` rules: exists:1 (found 0)\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched diff output - %s", buffer.String())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := JSON(&buffer, getErrors()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{".":{".png":["exists:1 (found 0)"]},"src/Not Kebab.ts":{".ts":["kebabcase"]}}` + "\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched json output - %s", buffer.String())
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := SARIF(&buffer, getErrors(), "dev", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buffer.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+
+	if log.Version != sarifVersion || len(log.Runs) != 1 {
+		t.Fatalf("unmatched sarif log - %+v", log)
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(rule.RulesIndex) {
+		t.Errorf("unmatched sarif driver rules - %+v", run.Tool.Driver.Rules)
+	}
+
+	tests := []*struct {
+		ruleID string
+		uri    string
+		rules  int
+	}{
+		{ruleID: "kebabcase", uri: "src/Not%20Kebab.ts", rules: 1},
+		{ruleID: "exists", uri: "./", rules: 1},
+	}
+
+	if len(run.Results) != len(tests) {
+		t.Fatalf("unmatched sarif results - %+v", run.Results)
+	}
+
+	for i, test := range tests {
+		result := run.Results[i]
+
+		if result.RuleID != test.ruleID || run.Tool.Driver.Rules[result.RuleIndex].ID != test.ruleID {
+			t.Errorf("Test %d failed with unmatched rule - %s", i, result.RuleID)
+		}
+
+		if uri := result.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != test.uri {
+			t.Errorf("Test %d failed with unmatched uri - %s", i, uri)
+		}
+
+		if len(result.Properties.Rules) != test.rules {
+			t.Errorf("Test %d failed with unmatched rules - %+v", i, result.Properties.Rules)
+		}
+	}
+}
+
+func TestSARIFDescriptions(t *testing.T) {
+	names := []string{rule.NewAnd(nil).GetName(), rule.NewOr(nil).GetName()}
+	for _, r := range rule.RulesIndex {
+		names = append(names, r.GetName())
+	}
+
+	// every rule has its own description instead of the fallback
+	for _, name := range names {
+		if _, ok := sarifDescriptions[name]; !ok {
+			t.Errorf("Test failed without description of rule %s", name)
+		}
+	}
+}
+
+func getSeverityErrors(severities ...string) []*rule.Error {
+	ruleErrors := make([]*rule.Error, 0, len(severities))
+	for _, severity := range severities {
+		ruleErrors = append(ruleErrors, &rule.Error{
+			Path:    "src/NotKebab.ts",
+			Ext:     ".ts",
+			Rules:   []rule.Rule{rule.NewAnnotated(rule.RulesIndex["kebabcase"], "", severity)},
+			RWMutex: new(sync.RWMutex),
+		})
+	}
+
+	return ruleErrors
+}
+
+func TestSeverity(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Text(&buffer, getSeverityErrors(rule.SeverityWarning)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "warning: src/NotKebab.ts failed for `.ts` rules: kebabcase\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched text output - %s", buffer.String())
+	}
+
+	buffer.Reset()
+	if err := JSON(&buffer, getSeverityErrors(rule.SeverityInfo)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = `{"src/NotKebab.ts":{".ts":["info: kebabcase"]}}` + "\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched json output - %s", buffer.String())
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []*struct {
+		severities  []string
+		maxWarnings int
+		expected    int
+	}{
+		{severities: []string{}, maxWarnings: -1, expected: 0},
+		{severities: []string{""}, maxWarnings: -1, expected: 1},
+		{severities: []string{rule.SeverityInfo, rule.SeverityError}, maxWarnings: -1, expected: 1},
+		{severities: []string{rule.SeverityWarning, rule.SeverityWarning}, maxWarnings: -1, expected: 0},
+		{severities: []string{rule.SeverityWarning, rule.SeverityWarning}, maxWarnings: 2, expected: 0},
+		{severities: []string{rule.SeverityWarning, rule.SeverityWarning}, maxWarnings: 1, expected: 1},
+		{severities: []string{rule.SeverityInfo, rule.SeverityInfo}, maxWarnings: 0, expected: 0},
+	}
+
+	var i = 0
+	for _, test := range tests {
+		if res := ExitCode(getSeverityErrors(test.severities...), test.maxWarnings); res != test.expected {
+			t.Errorf("Test %d failed with unmatched return value - %d", i, res)
+		}
+
+		i++
+	}
+}
+
+func TestDocs(t *testing.T) {
+	ruleErrors := []*rule.Error{
+		{
+			Path:    "src/NotKebab.ts",
+			Ext:     ".ts",
+			Rules:   []rule.Rule{rule.Document(rule.RulesIndex["kebabcase"], "components are named after their tag", "https://example.com/naming")},
+			RWMutex: new(sync.RWMutex),
+		},
+		{
+			Path:    "src/NotKebab.js",
+			Ext:     ".js",
+			Rules:   []rule.Rule{rule.Document(rule.RulesIndex["kebabcase"], "", "https://example.com/naming")},
+			RWMutex: new(sync.RWMutex),
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := Text(&buffer, ruleErrors); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "src/NotKebab.ts failed for `.ts` rules: kebabcase - components are named after their tag (https://example.com/naming)\n" +
+		"src/NotKebab.js failed for `.js` rules: kebabcase - https://example.com/naming\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched text output - %s", buffer.String())
+	}
+
+	buffer.Reset()
+	if err := JSON(&buffer, ruleErrors[:1]); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = `{"src/NotKebab.ts":{".ts":["kebabcase - components are named after their tag (https://example.com/naming)"]}}` + "\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched json output - %s", buffer.String())
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	ruleErrors := getErrors()
+	ruleErrors[0].IndexDir = "src"
+
+	tests := []*struct {
+		linted   map[string][]string
+		suites   []string
+		tests    int
+		failures int
+	}{
+		{linted: nil, suites: []string{".", "src"}, tests: 2, failures: 2},
+		{linted: map[string][]string{"src": {"src/Not Kebab.ts", "src/kebab.ts"}, "docs": {"docs/readme.md"}}, suites: []string{".", "docs", "src"}, tests: 4, failures: 2},
+	}
+
+	for i, test := range tests {
+		var buffer bytes.Buffer
+		if err := JUnit(&buffer, ruleErrors, test.linted); err != nil {
+			t.Fatal(err)
+		}
+
+		var report junitTestsuites
+		if err := xml.Unmarshal(buffer.Bytes(), &report); err != nil {
+			t.Fatal(err)
+		}
+
+		suites := make([]string, 0, len(report.Testsuites))
+		for _, suite := range report.Testsuites {
+			suites = append(suites, suite.Name)
+		}
+
+		if !reflect.DeepEqual(suites, test.suites) || report.Tests != test.tests || report.Failures != test.failures {
+			t.Errorf("Test %d failed with unmatched report - %s", i, buffer.String())
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := JUnit(&buffer, ruleErrors[:1], nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := xml.Header + `<testsuites name="ls-lint" tests="1" failures="1">
+  <testsuite name="src" tests="1" failures="1">
+    <testcase name="src/Not Kebab.ts" classname="src">
+      <failure message="src/Not Kebab.ts failed for ` + "`.ts`" + ` rules: kebabcase" type="error">kebabcase</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if buffer.String() != expected {
+		t.Errorf("unmatched junit output - %s", buffer.String())
+	}
+}
+
+func TestGitHub(t *testing.T) {
+	ruleErrors := append(getErrors(), getSeverityErrors(rule.SeverityWarning)...)
+
+	var buffer bytes.Buffer
+	if err := GitHub(&buffer, ruleErrors, "."); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "::error file=src/Not Kebab.ts,title=ls-lint (.ts)::src/Not Kebab.ts failed for `.ts` rules: kebabcase\n" +
+		"::error file=.,title=ls-lint (.png)::. failed for `.png` rules: exists:1 (found 0)\n" +
+		"::warning file=src/NotKebab.ts,title=ls-lint (.ts)::src/NotKebab.ts failed for `.ts` rules: kebabcase\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched github output - %s", buffer.String())
+	}
+
+	// annotation paths are resolved from the repository root
+	buffer.Reset()
+	if err := GitHub(&buffer, ruleErrors[:2], "./packages/app/"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = "::error file=packages/app/src/Not Kebab.ts,title=ls-lint (.ts)::src/Not Kebab.ts failed for `.ts` rules: kebabcase\n" +
+		"::error file=packages/app,title=ls-lint (.png)::. failed for `.png` rules: exists:1 (found 0)\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched github output with workdir - %s", buffer.String())
+	}
+
+	// absolute workdirs are resolved relative to the current working directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffer.Reset()
+	if err = GitHub(&buffer, ruleErrors[:2], filepath.Join(cwd, "packages", "app")); err != nil {
+		t.Fatal(err)
+	}
+
+	if buffer.String() != expected {
+		t.Errorf("unmatched github output with absolute workdir - %s", buffer.String())
+	}
+
+	if err = GitHub(&buffer, ruleErrors[:2], filepath.Dir(cwd)); err == nil {
+		t.Errorf("Test failed without workdir outside of the current working directory error")
+	}
+}
+
+func TestGitLab(t *testing.T) {
+	ruleErrors := append(getErrors(), getSeverityErrors(rule.SeverityInfo, rule.SeverityInfo)...)
+
+	var buffer bytes.Buffer
+	if err := GitLab(&buffer, ruleErrors, "."); err != nil {
+		t.Fatal(err)
+	}
+
+	var issues []gitlabIssue
+	if err := json.Unmarshal(buffer.Bytes(), &issues); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*struct {
+		checkName string
+		path      string
+		severity  string
+	}{
+		{checkName: "ls-lint.kebabcase", path: "src/Not Kebab.ts", severity: "major"},
+		{checkName: "ls-lint.exists", path: ".", severity: "major"},
+		{checkName: "ls-lint.kebabcase", path: "src/NotKebab.ts", severity: "info"},
+		{checkName: "ls-lint.kebabcase", path: "src/NotKebab.ts", severity: "info"},
+	}
+
+	if len(issues) != len(tests) {
+		t.Fatalf("unmatched gitlab issues - %+v", issues)
+	}
+
+	fingerprints := make(map[string]bool, len(issues))
+	for i, test := range tests {
+		issue := issues[i]
+
+		if issue.CheckName != test.checkName || issue.Location.Path != test.path || issue.Severity != test.severity {
+			t.Errorf("Test %d failed with unmatched issue - %+v", i, issue)
+		}
+
+		if fingerprints[issue.Fingerprint] {
+			t.Errorf("Test %d failed with duplicated fingerprint - %s", i, issue.Fingerprint)
+		}
+
+		fingerprints[issue.Fingerprint] = true
+	}
+
+	// fingerprints are stable across runs
+	var rerun bytes.Buffer
+	if err := GitLab(&rerun, ruleErrors, "."); err != nil {
+		t.Fatal(err)
+	}
+
+	if rerun.String() != buffer.String() {
+		t.Errorf("unmatched gitlab output on rerun - %s", rerun.String())
+	}
+
+	// location paths are resolved from the repository root
+	rerun.Reset()
+	if err := GitLab(&rerun, ruleErrors[:1], "packages/app"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal(rerun.Bytes(), &issues); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(issues) != 1 || issues[0].Location.Path != "packages/app/src/Not Kebab.ts" {
+		t.Errorf("unmatched gitlab issues with workdir - %+v", issues)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rerun.Reset()
+	if err = GitLab(&rerun, ruleErrors[:1], filepath.Join(cwd, "packages", "app")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = json.Unmarshal(rerun.Bytes(), &issues); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(issues) != 1 || issues[0].Location.Path != "packages/app/src/Not Kebab.ts" {
+		t.Errorf("unmatched gitlab issues with absolute workdir - %+v", issues)
+	}
+}
+
+func TestCheckstyle(t *testing.T) {
+	ruleErrors := append(getErrors(), getSeverityErrors(rule.SeverityWarning)...)
+	ruleErrors[0].Rules = append(ruleErrors[0].Rules, rule.NewAnnotated(rule.RulesIndex["lowercase"], "", rule.SeverityInfo))
+
+	var buffer bytes.Buffer
+	if err := Checkstyle(&buffer, ruleErrors); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := xml.Header + `<checkstyle version="4.3">
+  <file name=".">
+    <error line="1" severity="error" message="exists:1 (found 0)" source="ls-lint.exists"></error>
+  </file>
+  <file name="src/Not Kebab.ts">
+    <error line="1" severity="error" message="kebabcase" source="ls-lint.kebabcase"></error>
+    <error line="1" severity="info" message="lowercase" source="ls-lint.lowercase"></error>
+  </file>
+  <file name="src/NotKebab.ts">
+    <error line="1" severity="warning" message="kebabcase" source="ls-lint.kebabcase"></error>
+  </file>
+</checkstyle>
+`
+	if buffer.String() != expected {
+		t.Errorf("unmatched checkstyle output - %s", buffer.String())
+	}
+}
+
+func TestJSONv2(t *testing.T) {
+	ruleErrors := getErrors()
+	ruleErrors[0].IndexDir = "src"
+
+	statistic := debug.NewStatistic()
+	statistic.AddFile()
+	statistic.AddDir()
+	statistic.AddDirSkip()
+
+	var buffer bytes.Buffer
+	if err := JSONv2(&buffer, ruleErrors, statistic); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"version":2,"errors":[` +
+		`{"path":"src/Not Kebab.ts","kind":"file","extension_key":".ts","config_dir":"src","severity":"error","rules":[{"name":"kebabcase","params":[],"message":"kebabcase"}]},` +
+		`{"path":".","kind":"dir","extension_key":".png","config_dir":".","severity":"error","rules":[{"name":"exists","params":["1"],"message":"exists:1 (found 0)","found":0}]}` +
+		`],"summary":{"files":1,"file_skips":0,"dirs":1,"dir_skips":1,"errors":2}}` + "\n"
+	if buffer.String() != expected {
+		t.Errorf("unmatched json v2 output - %s", buffer.String())
+	}
+}
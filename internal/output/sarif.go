@@ -0,0 +1,258 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifSrcRoot = "%SRCROOT%"
+	sarifInfoURI = "https://ls-lint.org"
+)
+
+var sarifDescriptions = map[string]string{
+	"lowercase":          "Every letter of the name must be lowercase",
+	"regex":              "The name must match the given regex pattern",
+	"exists":             "The number of matching files or directories must be within the given range",
+	"camelcase":          "The name must be camelCase",
+	"pascalcase":         "The name must be PascalCase",
+	"snakecase":          "The name must be snake_case",
+	"screamingsnakecase": "The name must be SCREAMING_SNAKE_CASE",
+	"kebabcase":          "The name must be kebab-case",
+	"length":             "The number of characters of the name must be within the given range",
+	"portable":           "The name must be portable across posix and windows filesystems",
+	"prefix":             "The name must start with the given prefix",
+	"suffix":             "The name must end with the given suffix",
+	"unique-casefold":    "The names of a directory must not collide on case-insensitive filesystems",
+	"and":                "The name must satisfy all rules of the expression",
+	"or":                 "The name must satisfy any rule of the expression",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string                `json:"ruleId"`
+	RuleIndex  int                   `json:"ruleIndex"`
+	Level      string                `json:"level"`
+	Message    sarifMessage          `json:"message"`
+	Locations  []sarifLocation       `json:"locations"`
+	Properties sarifResultProperties `json:"properties"`
+}
+
+type sarifResultProperties struct {
+	Extension string            `json:"extension"`
+	Help      string            `json:"help,omitempty"`
+	URL       string            `json:"url,omitempty"`
+	Rules     []sarifResultRule `json:"rules"`
+}
+
+type sarifResultRule struct {
+	Name       string   `json:"name"`
+	Parameters []string `json:"parameters"`
+	Message    string   `json:"message"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// SARIF writes the errors as SARIF 2.1.0 log
+// artifact uris are relative to the workdir which is referenced as %SRCROOT%
+func SARIF(writer io.Writer, ruleErrors []*rule.Error, version string, workdir string) (err error) {
+	driver := sarifDriver{
+		Name:           "ls-lint",
+		Version:        version,
+		InformationURI: sarifInfoURI,
+		Rules:          make([]sarifRule, 0, len(rule.RulesIndex)),
+	}
+
+	ruleNames := make([]string, 0, len(rule.RulesIndex))
+	for name := range rule.RulesIndex {
+		ruleNames = append(ruleNames, name)
+	}
+	slices.Sort(ruleNames)
+
+	ruleIndex := make(map[string]int, len(ruleNames))
+	for _, name := range ruleNames {
+		ruleIndex[name] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:               name,
+			Name:             name,
+			ShortDescription: sarifMessage{Text: sarifDescription(name)},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(ruleErrors))
+	for _, ruleErr := range ruleErrors {
+		rules := ruleErr.GetFailedRules()
+		if len(rules) == 0 {
+			continue
+		}
+
+		resultRules := make([]sarifResultRule, 0, len(rules))
+		for _, errRule := range rules {
+			if _, ok := ruleIndex[errRule.GetName()]; !ok { // custom rules
+				ruleIndex[errRule.GetName()] = len(driver.Rules)
+				driver.Rules = append(driver.Rules, sarifRule{
+					ID:               errRule.GetName(),
+					Name:             errRule.GetName(),
+					ShortDescription: sarifMessage{Text: sarifDescription(errRule.GetName())},
+				})
+			}
+
+			parameters := errRule.GetParameters()
+			if parameters == nil {
+				parameters = make([]string, 0)
+			}
+
+			resultRules = append(resultRules, sarifResultRule{
+				Name:       errRule.GetName(),
+				Parameters: parameters,
+				Message:    errRule.GetErrorMessage(),
+			})
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    rules[0].GetName(),
+			RuleIndex: ruleIndex[rules[0].GetName()],
+			Level:     sarifLevel(ruleErr.GetSeverity()),
+			Message: sarifMessage{
+				Text: Message(ruleErr),
+			},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{
+							URI:       sarifURI(ruleErr),
+							URIBaseID: sarifSrcRoot,
+						},
+					},
+				},
+			},
+			Properties: sarifResultProperties{
+				Extension: ruleErr.GetExt(),
+				Help:      ruleErr.GetHelp(),
+				URL:       ruleErr.GetURL(),
+				Rules:     resultRules,
+			},
+		})
+	}
+
+	run := sarifRun{
+		Tool:    sarifTool{Driver: driver},
+		Results: results,
+	}
+
+	if workdir != "" {
+		var abs string
+		if abs, err = filepath.Abs(workdir); err != nil {
+			return err
+		}
+
+		abs = filepath.ToSlash(abs)
+		if !strings.HasPrefix(abs, "/") { // windows
+			abs = "/" + abs
+		}
+
+		run.OriginalURIBaseIDs = map[string]sarifArtifactLocation{
+			sarifSrcRoot: {URI: (&url.URL{Scheme: "file", Path: strings.TrimSuffix(abs, "/") + "/"}).String()},
+		}
+	}
+
+	var jsonStr []byte
+	if jsonStr, err = json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}, "", "  "); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(writer, string(jsonStr))
+	return err
+}
+
+func sarifDescription(name string) string {
+	if description, ok := sarifDescriptions[name]; ok {
+		return description
+	}
+
+	return fmt.Sprintf("The name must satisfy the %s rule", name)
+}
+
+// sarifLevel returns the result level of the severity
+func sarifLevel(severity string) string {
+	switch severity {
+	case rule.SeverityWarning:
+		return "warning"
+	case rule.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+// sarifURI returns the relative artifact uri of the error path
+// directories end with a trailing slash
+func sarifURI(ruleErr *rule.Error) string {
+	path := ruleErr.GetPath()
+
+	if path == "" {
+		return "./"
+	}
+
+	if ruleErr.IsDir() || ruleErr.GetExt() == ".dir" {
+		path += "/"
+	}
+
+	return (&url.URL{Path: filepath.ToSlash(path)}).String()
+}
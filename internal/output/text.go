@@ -0,0 +1,39 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+func Text(writer io.Writer, ruleErrors []*rule.Error) (err error) {
+	for _, ruleErr := range ruleErrors {
+		if _, err = fmt.Fprintln(writer, textLine(ruleErr)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Diff writes the introduced errors prefixed with + and the resolved errors prefixed with -
+func Diff(writer io.Writer, introduced []*rule.Error, resolved []*rule.Error) (err error) {
+	for _, ruleErr := range introduced {
+		if _, err = fmt.Fprintf(writer, "+ %s\n", textLine(ruleErr)); err != nil {
+			return err
+		}
+	}
+
+	for _, ruleErr := range resolved {
+		if _, err = fmt.Fprintf(writer, "- %s\n", textLine(ruleErr)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func textLine(ruleErr *rule.Error) string {
+	return getSeverityPrefix(ruleErr) + Message(ruleErr)
+}
@@ -0,0 +1,272 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const affixSep = "+"
+
+// Explainer is implemented by rules which can explain why a value failed
+type Explainer interface {
+	// Explain returns a copy of the rule whose error message describes the failure of the value
+	Explain(value string) Rule
+}
+
+// affix is the shared implementation of the prefix and suffix rules
+// the optional rule after the affix validates the remainder of the value
+type affix struct {
+	name      string
+	exclusive bool
+	suffix    bool
+	text      string
+	spec      string
+	remainder Rule
+	failure   string
+	*sync.RWMutex
+}
+
+func (rule *affix) init(name string, suffix bool) {
+	rule.name = name
+	rule.exclusive = false
+	rule.suffix = suffix
+	rule.RWMutex = new(sync.RWMutex)
+}
+
+func (rule *affix) GetName() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.name
+}
+
+// 0 = affix text - optionally followed by +rule to validate the remainder (e.g. use+PascalCase)
+func (rule *affix) SetParameters(params []string) error {
+	rule.Lock()
+	defer rule.Unlock()
+
+	// prefix
+	if len(params) == 0 {
+		return fmt.Errorf("%s value not exists", rule.name)
+	}
+
+	// prefix:use+PascalCase
+	text, spec, withRemainder := strings.Cut(params[0], affixSep)
+	if text == "" {
+		return fmt.Errorf("%s value is empty", rule.name)
+	}
+
+	var remainder Rule
+	if withRemainder {
+		ruleName, ruleParams, withParams := strings.Cut(spec, ":")
+
+		r, ok := Rules[ruleName]
+		if !ok {
+			return fmt.Errorf("rule %s not exists", ruleName)
+		}
+
+		if r.GetExclusive() {
+			return fmt.Errorf("rule %s can't be combined with %s", ruleName, rule.name)
+		}
+
+		remainder = r.Copy()
+
+		var remainderParams []string
+		if withParams {
+			remainderParams = []string{ruleParams}
+		}
+
+		if err := remainder.SetParameters(remainderParams); err != nil {
+			return fmt.Errorf("rule %s failed with %s", ruleName, err.Error())
+		}
+	}
+
+	rule.text = text
+	rule.spec = spec
+	rule.remainder = remainder
+	return nil
+}
+
+// SetOptions sets the text option - the remainder option is the rule for the remainder
+func (rule *affix) SetOptions(options Options) error {
+	if err := options.only("text", "remainder"); err != nil {
+		return err
+	}
+
+	value := options["text"]
+	if remainder, ok := options["remainder"]; ok {
+		value = value + affixSep + remainder
+	}
+
+	return rule.SetParameters([]string{value})
+}
+
+func (rule *affix) GetParameters() []string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	if rule.remainder == nil {
+		return []string{rule.text}
+	}
+
+	return []string{rule.text + affixSep + rule.spec}
+}
+
+func (rule *affix) GetExclusive() bool {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.exclusive
+}
+
+// strip returns the value without the affix and false if the affix is missing
+func (rule *affix) strip(value string) (string, bool) {
+	if rule.suffix {
+		return strings.TrimSuffix(value, rule.text), strings.HasSuffix(value, rule.text)
+	}
+
+	return strings.TrimPrefix(value, rule.text), strings.HasPrefix(value, rule.text)
+}
+
+// Validate checks if the value has the affix and the remainder is valid
+func (rule *affix) Validate(value string, path string, fail bool) (bool, error) {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	remainder, ok := rule.strip(value)
+	if !ok {
+		return false, nil
+	}
+
+	if rule.remainder == nil {
+		return true, nil
+	}
+
+	if remainder == "" {
+		return false, nil
+	}
+
+	return rule.remainder.Validate(remainder, path, fail)
+}
+
+// Suggest adds the missing affix and converts the remainder
+func (rule *affix) Suggest(value string) (string, bool) {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	remainder, _ := rule.strip(value)
+	if rule.remainder != nil {
+		suggester, ok := rule.remainder.(Suggester)
+		if !ok {
+			return "", false
+		}
+
+		if remainder, ok = suggester.Suggest(remainder); !ok || remainder == "" {
+			return "", false
+		}
+	}
+
+	if rule.suffix {
+		return remainder + rule.text, true
+	}
+
+	return rule.text + remainder, true
+}
+
+// explain returns the failure of the value
+func (rule *affix) explain(value string) string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	remainder, ok := rule.strip(value)
+	if !ok {
+		return fmt.Sprintf("missing %s `%s`", rule.name, rule.text)
+	}
+
+	message := rule.spec
+	if explainer, ok := rule.remainder.(Explainer); ok && remainder != "" {
+		message = explainer.Explain(remainder).GetErrorMessage()
+	}
+
+	if rule.suffix {
+		return fmt.Sprintf("%s before suffix `%s`", message, rule.text)
+	}
+
+	return fmt.Sprintf("%s after prefix `%s`", message, rule.text)
+}
+
+func (rule *affix) GetErrorMessage() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	if rule.failure != "" {
+		return rule.failure
+	}
+
+	if rule.remainder == nil {
+		return fmt.Sprintf("%s:%s", rule.name, rule.text)
+	}
+
+	return fmt.Sprintf("%s:%s%s%s", rule.name, rule.text, affixSep, rule.spec)
+}
+
+func (rule *affix) copyTo(c *affix) {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	c.text = rule.text
+	c.spec = rule.spec
+	c.remainder = rule.remainder
+	c.failure = rule.failure
+}
+
+type Prefix struct {
+	affix
+}
+
+func (rule *Prefix) Init() Rule {
+	rule.init("prefix", false)
+
+	return rule
+}
+
+func (rule *Prefix) Explain(value string) Rule {
+	c := rule.Copy().(*Prefix)
+	c.failure = rule.explain(value)
+
+	return c
+}
+
+func (rule *Prefix) Copy() Rule {
+	c := new(Prefix)
+	c.Init()
+	rule.copyTo(&c.affix)
+
+	return c
+}
+
+type Suffix struct {
+	affix
+}
+
+func (rule *Suffix) Init() Rule {
+	rule.init("suffix", true)
+
+	return rule
+}
+
+func (rule *Suffix) Explain(value string) Rule {
+	c := rule.Copy().(*Suffix)
+	c.failure = rule.explain(value)
+
+	return c
+}
+
+func (rule *Suffix) Copy() Rule {
+	c := new(Suffix)
+	c.Init()
+	rule.copyTo(&c.affix)
+
+	return c
+}
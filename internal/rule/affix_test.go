@@ -0,0 +1,146 @@
+package rule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAffix_SetParameters(t *testing.T) {
+	tests := []*struct {
+		rule     Rule
+		params   []string
+		expected []string
+	}{
+		{rule: new(Prefix).Init(), params: []string{"use"}, expected: []string{"use"}},
+		{rule: new(Prefix).Init(), params: []string{"use+PascalCase"}, expected: []string{"use+PascalCase"}},
+		{rule: new(Suffix).Init(), params: []string{"Hook+regex:[A-Z][a-z]+"}, expected: []string{"Hook+regex:[A-Z][a-z]+"}},
+		{rule: new(Prefix).Init(), params: []string{"use+suffix:Hook+PascalCase"}, expected: []string{"use+suffix:Hook+PascalCase"}},
+	}
+
+	i := 0
+	for _, test := range tests {
+		if err := test.rule.SetParameters(test.params); err != nil {
+			t.Errorf("Test %d failed with unmatched error - %s", i, err.Error())
+			return
+		}
+
+		params := test.rule.GetParameters()
+		if !reflect.DeepEqual(params, test.expected) {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, params)
+			return
+		}
+
+		i++
+	}
+
+	for _, params := range [][]string{{}, {""}, {"+PascalCase"}, {"use+unknown"}, {"use+exists:1"}, {"use+regex:["}} {
+		if err := new(Prefix).Init().SetParameters(params); err == nil {
+			t.Errorf("Test %+v failed without error", params)
+		}
+	}
+}
+
+func TestAffix_Validate(t *testing.T) {
+	tests := []*struct {
+		rule     Rule
+		params   []string
+		value    string
+		expected bool
+	}{
+		{rule: new(Prefix).Init(), params: []string{"use"}, value: "useState", expected: true},
+		{rule: new(Prefix).Init(), params: []string{"use"}, value: "state", expected: false},
+		{rule: new(Prefix).Init(), params: []string{"use+PascalCase"}, value: "useState", expected: true},
+		{rule: new(Prefix).Init(), params: []string{"use+PascalCase"}, value: "usestate", expected: false},
+		{rule: new(Prefix).Init(), params: []string{"use+PascalCase"}, value: "use", expected: false},
+		{rule: new(Suffix).Init(), params: []string{"Controller+PascalCase"}, value: "UserController", expected: true},
+		{rule: new(Suffix).Init(), params: []string{"Controller+PascalCase"}, value: "userController", expected: false},
+		{rule: new(Suffix).Init(), params: []string{"_test+snake_case"}, value: "user_test", expected: true},
+		{rule: new(Suffix).Init(), params: []string{"_test+snake_case"}, value: "user", expected: false},
+		{rule: new(Prefix).Init(), params: []string{"use+suffix:Hook+PascalCase"}, value: "useStateHook", expected: true},
+		{rule: new(Prefix).Init(), params: []string{"use+suffix:Hook+PascalCase"}, value: "useState", expected: false},
+	}
+
+	i := 0
+	for _, test := range tests {
+		if err := test.rule.SetParameters(test.params); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := test.rule.Validate(test.value, "", true)
+		if err != nil {
+			t.Errorf("Test %d failed with unmatched error - %s", i, err.Error())
+			return
+		}
+
+		if res != test.expected {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, res)
+			return
+		}
+
+		i++
+	}
+}
+
+func TestAffix_Explain(t *testing.T) {
+	tests := []*struct {
+		rule     Rule
+		params   []string
+		value    string
+		expected string
+	}{
+		{rule: new(Prefix).Init(), params: []string{"use+PascalCase"}, value: "state", expected: "missing prefix `use`"},
+		{rule: new(Prefix).Init(), params: []string{"use+PascalCase"}, value: "usestate", expected: "PascalCase after prefix `use`"},
+		{rule: new(Suffix).Init(), params: []string{"Controller"}, value: "User", expected: "missing suffix `Controller`"},
+		{rule: new(Suffix).Init(), params: []string{"Controller+PascalCase"}, value: "userController", expected: "PascalCase before suffix `Controller`"},
+		{rule: new(Prefix).Init(), params: []string{"use+suffix:Hook+PascalCase"}, value: "useState", expected: "missing suffix `Hook` after prefix `use`"},
+	}
+
+	i := 0
+	for _, test := range tests {
+		if err := test.rule.SetParameters(test.params); err != nil {
+			t.Fatal(err)
+		}
+
+		message := test.rule.(Explainer).Explain(test.value).GetErrorMessage()
+		if message != test.expected {
+			t.Errorf("Test %d failed with unmatched return value - %s", i, message)
+			return
+		}
+
+		if test.rule.GetErrorMessage() != test.rule.GetName()+":"+test.params[0] {
+			t.Errorf("Test %d failed with modified rule - %s", i, test.rule.GetErrorMessage())
+			return
+		}
+
+		i++
+	}
+}
+
+func TestAffix_Suggest(t *testing.T) {
+	tests := []*struct {
+		rule     Rule
+		params   []string
+		value    string
+		expected string
+	}{
+		{rule: new(Prefix).Init(), params: []string{"use"}, value: "state", expected: "usestate"},
+		{rule: new(Prefix).Init(), params: []string{"use+PascalCase"}, value: "state", expected: "useState"},
+		{rule: new(Prefix).Init(), params: []string{"use+PascalCase"}, value: "use-local-state", expected: "useLocalState"},
+		{rule: new(Suffix).Init(), params: []string{".controller+kebab-case"}, value: "UserAuth", expected: "user-auth.controller"},
+	}
+
+	i := 0
+	for _, test := range tests {
+		if err := test.rule.SetParameters(test.params); err != nil {
+			t.Fatal(err)
+		}
+
+		suggestion, ok := test.rule.(Suggester).Suggest(test.value)
+		if !ok || suggestion != test.expected {
+			t.Errorf("Test %d failed with unmatched return value - %s", i, suggestion)
+			return
+		}
+
+		i++
+	}
+}
@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"strings"
 	"sync"
 	"unicode"
 )
@@ -44,7 +45,8 @@ func (rule *CamelCase) GetExclusive() bool {
 // Validate checks if string is camel case
 // false if rune is no letter and no digit
 func (rule *CamelCase) Validate(value string, _ string, _ bool) (bool, error) {
-	for i, c := range value {
+	runes := []rune(value)
+	for i, c := range runes {
 		// must be letter or digit
 		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
 			return false, nil
@@ -57,17 +59,17 @@ func (rule *CamelCase) Validate(value string, _ string, _ bool) (bool, error) {
 			}
 
 			// rune -1 can be digit
-			if unicode.IsDigit(rune(value[i-1])) {
+			if unicode.IsDigit(runes[i-1]) {
 				continue
 			}
 
 			// allow cases like ssrVFor.ts
-			if i >= 2 && unicode.IsUpper(rune(value[i-1])) && unicode.IsLower(rune(value[i-2])) {
+			if i >= 2 && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i-2]) {
 				continue
 			}
 
 			// rune -1 must be lower
-			if !unicode.IsLower(rune(value[i-1])) {
+			if !unicode.IsLower(runes[i-1]) {
 				return false, nil
 			}
 		}
@@ -76,6 +78,22 @@ func (rule *CamelCase) Validate(value string, _ string, _ bool) (bool, error) {
 	return true, nil
 }
 
+// Suggest converts the value to camel case
+func (rule *CamelCase) Suggest(value string) (string, bool) {
+	return suggest(rule, joinWords(splitWords(value), "", func(i int, word string) string {
+		if i == 0 {
+			return strings.ToLower(word)
+		}
+
+		return title(word)
+	}))
+}
+
+// ExplainName explains the failure with the expected camel case name
+func (rule *CamelCase) ExplainName(value string, rest string) Rule {
+	return expect(rule, "camelCase", value, rest)
+}
+
 func (rule *CamelCase) GetErrorMessage() string {
 	return rule.GetName()
 }
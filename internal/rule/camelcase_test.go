@@ -22,6 +22,8 @@ func TestCamelCase(t *testing.T) {
 		{value: "CAMELCASE", expected: false, err: nil},
 		{value: "camel_case", expected: false, err: nil},
 		{value: "camel.case", expected: false, err: nil},
+		{value: "überGröße", expected: true, err: nil},
+		{value: "überGRÖßE", expected: false, err: nil},
 	}
 
 	i := 0
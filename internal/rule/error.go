@@ -3,10 +3,11 @@ package rule
 import "sync"
 
 type Error struct {
-	Path  string
-	Dir   bool
-	Ext   string
-	Rules []Rule
+	Path     string
+	Dir      bool
+	Ext      string
+	IndexDir string
+	Rules    []Rule
 	*sync.RWMutex
 }
 
@@ -31,9 +32,74 @@ func (err *Error) GetExt() string {
 	return err.Ext
 }
 
+// GetIndexDir returns the dir of the rule index whose rules failed
+func (err *Error) GetIndexDir() string {
+	err.RLock()
+	defer err.RUnlock()
+
+	return err.IndexDir
+}
+
 func (err *Error) GetRules() []Rule {
 	err.RLock()
 	defer err.RUnlock()
 
 	return err.Rules
 }
+
+// GetFailedRules returns the rules to report
+// exists rules are only reported on exists errors
+func (err *Error) GetFailedRules() []Rule {
+	err.RLock()
+	defer err.RUnlock()
+
+	rules := make([]Rule, 0, len(err.Rules))
+	for _, rule := range err.Rules {
+		if !err.Dir && rule.GetName() == "exists" {
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// GetSeverity returns the highest severity of the failed rules
+func (err *Error) GetSeverity() string {
+	rules := err.GetFailedRules()
+	if len(rules) == 0 {
+		return SeverityError
+	}
+
+	severity := SeverityInfo
+	for _, rule := range rules {
+		if ruleSeverity := SeverityOf(rule); HigherSeverity(ruleSeverity, severity) {
+			severity = ruleSeverity
+		}
+	}
+
+	return severity
+}
+
+// GetHelp returns the help message of the rule set of the first failed rule with a help message
+func (err *Error) GetHelp() string {
+	for _, rule := range err.GetFailedRules() {
+		if annotated, ok := rule.(*Annotated); ok && annotated.GetHelp() != "" {
+			return annotated.GetHelp()
+		}
+	}
+
+	return ""
+}
+
+// GetURL returns the documentation url of the rule set of the first failed rule with an url
+func (err *Error) GetURL() string {
+	for _, rule := range err.GetFailedRules() {
+		if annotated, ok := rule.(*Annotated); ok && annotated.GetURL() != "" {
+			return annotated.GetURL()
+		}
+	}
+
+	return ""
+}
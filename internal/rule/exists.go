@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -9,6 +10,8 @@ import (
 	"sync"
 )
 
+var ErrInvalidRange = errors.New("min is greater than max")
+
 type Exists struct {
 	name      string
 	exclusive bool
@@ -53,37 +56,67 @@ func (rule *Exists) SetParameters(params []string) error {
 		return fmt.Errorf("exists value is empty")
 	}
 
-	// exists:1
-	split := strings.Split(params[0], "-")
-	if len(split) == 1 {
-		var value int64
-		var err error
+	// exists:1 or exists:1-4
+	minValue, maxValue, err := parseRange(params[0])
+	if err != nil {
+		return err
+	}
 
-		if value, err = strconv.ParseInt(params[0], 10, 16); err != nil {
-			return err.(*strconv.NumError).Err
-		}
+	rule.min = minValue
+	rule.max = maxValue
+	return nil
+}
 
-		rule.min = uint16(value)
-		rule.max = uint16(value)
-		return nil
+// SetOptions sets the min and max options - min defaults to 0 and max to the maximum if only one is set
+func (rule *Exists) SetOptions(options Options) error {
+	if err := options.only("min", "max"); err != nil {
+		return err
 	}
 
-	// exists:1-4
+	minValue, withMin := options["min"]
+	maxValue, withMax := options["max"]
+	switch {
+	case !withMin && !withMax:
+		return rule.SetParameters([]string{})
+	case !withMin:
+		minValue = "0"
+	case !withMax:
+		maxValue = fmt.Sprintf("%d", math.MaxInt16)
+	}
+
+	return rule.SetParameters([]string{fmt.Sprintf("%s-%s", minValue, maxValue)})
+}
+
+// parseRange parses a single value (min = max) or a min-max range
+func parseRange(value string) (uint16, uint16, error) {
 	var minValue int64
 	var maxValue int64
 	var err error
 
+	// 1
+	split := strings.Split(value, "-")
+	if len(split) == 1 {
+		if minValue, err = strconv.ParseInt(value, 10, 16); err != nil {
+			return 0, 0, err.(*strconv.NumError).Err
+		}
+
+		return uint16(minValue), uint16(minValue), nil
+	}
+
+	// 1-4
 	if minValue, err = strconv.ParseInt(split[0], 10, 16); err != nil {
-		return err.(*strconv.NumError).Err
+		return 0, 0, err.(*strconv.NumError).Err
 	}
 
 	if maxValue, err = strconv.ParseInt(split[1], 10, 16); err != nil {
-		return err.(*strconv.NumError).Err
+		return 0, 0, err.(*strconv.NumError).Err
 	}
 
-	rule.min = uint16(minValue)
-	rule.max = uint16(maxValue)
-	return nil
+	if minValue > maxValue {
+		return 0, 0, ErrInvalidRange
+	}
+
+	return uint16(minValue), uint16(maxValue), nil
 }
 
 func (rule *Exists) GetParameters() []string {
@@ -107,7 +140,7 @@ func (rule *Exists) Validate(value string, _ string, fail bool) (bool, error) {
 		return true, nil
 	}
 
-	return rule.getCount() >= rule.getMin() && rule.getCount() <= rule.getMax(), nil
+	return rule.GetCount() >= rule.getMin() && rule.GetCount() <= rule.getMax(), nil
 }
 
 func (rule *Exists) getMin() uint16 {
@@ -124,7 +157,8 @@ func (rule *Exists) getMax() uint16 {
 	return rule.max
 }
 
-func (rule *Exists) getCount() uint16 {
+// GetCount returns the number of found files or directories
+func (rule *Exists) GetCount() uint16 {
 	rule.RLock()
 	defer rule.RUnlock()
 
@@ -140,10 +174,10 @@ func (rule *Exists) incrementCount() {
 
 func (rule *Exists) GetErrorMessage() string {
 	if rule.getMin() == rule.getMax() {
-		return fmt.Sprintf("%s:%d (found %d)", rule.GetName(), rule.getMin(), rule.getCount())
+		return fmt.Sprintf("%s:%d (found %d)", rule.GetName(), rule.getMin(), rule.GetCount())
 	}
 
-	return fmt.Sprintf("%s:%d-%d (found %d)", rule.GetName(), rule.getMin(), rule.getMax(), rule.getCount())
+	return fmt.Sprintf("%s:%d-%d (found %d)", rule.GetName(), rule.getMin(), rule.getMax(), rule.GetCount())
 }
 
 func (rule *Exists) Copy() Rule {
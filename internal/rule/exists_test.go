@@ -21,6 +21,7 @@ func TestExists_GetParameters(t *testing.T) {
 		{params: []string{"2342323423234"}, expected: []string{"0"}, err: strconv.ErrRange},
 		{params: []string{"1-"}, expected: []string{"0"}, err: strconv.ErrSyntax},
 		{params: []string{"1-2342323423234"}, expected: []string{"0"}, err: strconv.ErrRange},
+		{params: []string{"4-1"}, expected: []string{"0"}, err: ErrInvalidRange},
 	}
 
 	i := 0
@@ -0,0 +1,240 @@
+package rule
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	and = " & "
+	or  = " | "
+)
+
+// ValueFunc returns the value to validate by the rule
+// the value without extensions or the basename for rules which validate the basename
+type ValueFunc func(r Rule) string
+
+// Evaluate validates the rule - expressions validate every operand with its own value
+func Evaluate(r Rule, value ValueFunc, path string, fail bool) (bool, error) {
+	if expression, ok := Unwrap(r).(*Expression); ok {
+		return expression.Evaluate(value, path, fail)
+	}
+
+	return r.Validate(value(r), path, fail)
+}
+
+// Expression combines rules with AND (&) or OR (|)
+// expressions are created by the config for grouped rules (a & b, (a | b)) and are not available by name
+type Expression struct {
+	name      string
+	exclusive bool
+	all       bool
+	operands  []Rule
+	failure   string
+	*sync.RWMutex
+}
+
+// NewAnd returns an expression which is valid if all operands are valid
+func NewAnd(operands []Rule) *Expression {
+	expression := &Expression{all: true, operands: operands}
+	expression.Init()
+
+	return expression
+}
+
+// NewOr returns an expression which is valid if any operand is valid
+func NewOr(operands []Rule) *Expression {
+	expression := &Expression{all: false, operands: operands}
+	expression.Init()
+
+	return expression
+}
+
+func (rule *Expression) Init() Rule {
+	rule.name = "or"
+	if rule.all {
+		rule.name = "and"
+	}
+
+	rule.exclusive = false
+	rule.RWMutex = new(sync.RWMutex)
+
+	return rule
+}
+
+func (rule *Expression) GetName() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.name
+}
+
+func (rule *Expression) SetParameters(params []string) error {
+	return nil
+}
+
+func (rule *Expression) GetParameters() []string {
+	return nil
+}
+
+func (rule *Expression) GetExclusive() bool {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.exclusive
+}
+
+func (rule *Expression) GetOperands() []Rule {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.operands
+}
+
+// Validate validates all operands with the same value
+func (rule *Expression) Validate(value string, path string, fail bool) (bool, error) {
+	return rule.Evaluate(func(Rule) string { return value }, path, fail)
+}
+
+// Evaluate validates the operands with their values
+func (rule *Expression) Evaluate(value ValueFunc, path string, fail bool) (bool, error) {
+	for _, operand := range rule.GetOperands() {
+		valid, err := Evaluate(operand, value, path, fail)
+		if err != nil {
+			return false, err
+		}
+
+		if valid != rule.all {
+			return valid, nil
+		}
+	}
+
+	return rule.all, nil
+}
+
+// ExplainWith returns a copy of the expression whose error message contains only the failed operands
+// rest returns the part of the name which is not validated by the operand (e.g. the extensions)
+func (rule *Expression) ExplainWith(value ValueFunc, rest ValueFunc, path string) Rule {
+	c := rule.Copy().(*Expression)
+	c.failure = rule.explain(value, rest, path)
+
+	return c
+}
+
+func (rule *Expression) explain(value ValueFunc, rest ValueFunc, path string) string {
+	messages := make([]string, 0, len(rule.GetOperands()))
+	for _, operand := range rule.GetOperands() {
+		if valid, err := Evaluate(operand, value, path, true); err == nil && valid {
+			continue
+		}
+
+		if expression, ok := operand.(*Expression); ok {
+			messages = append(messages, rule.wrap(expression, expression.explain(value, rest, path)))
+			continue
+		}
+
+		messages = append(messages, ExplainRule(operand, value(operand), rest(operand)).GetErrorMessage())
+	}
+
+	return strings.Join(messages, rule.operator())
+}
+
+func (rule *Expression) operator() string {
+	if rule.all {
+		return and
+	}
+
+	return or
+}
+
+// wrap adds parentheses to OR operands of AND expressions
+func (rule *Expression) wrap(operand *Expression, message string) string {
+	if rule.all && !operand.all {
+		return "(" + message + ")"
+	}
+
+	return message
+}
+
+func (rule *Expression) GetErrorMessage() string {
+	rule.RLock()
+	failure := rule.failure
+	rule.RUnlock()
+
+	if failure != "" {
+		return failure
+	}
+
+	messages := make([]string, 0, len(rule.GetOperands()))
+	for _, operand := range rule.GetOperands() {
+		if expression, ok := operand.(*Expression); ok {
+			messages = append(messages, rule.wrap(expression, expression.GetErrorMessage()))
+			continue
+		}
+
+		messages = append(messages, operand.GetErrorMessage())
+	}
+
+	return strings.Join(messages, rule.operator())
+}
+
+func (rule *Expression) Copy() Rule {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	operands := make([]Rule, 0, len(rule.operands))
+	for _, operand := range rule.operands {
+		operands = append(operands, operand.Copy())
+	}
+
+	c := &Expression{all: rule.all, operands: operands}
+	c.Init()
+
+	return c
+}
+
+// Except returns the rule without the rules of the excepted names and the removed rules
+// excepted rules are valid - nil is returned if the rule is valid because of an excepted rule
+func Except(r Rule, names map[string]bool) (Rule, []Rule) {
+	if names[r.GetName()] {
+		return nil, []Rule{r}
+	}
+
+	expression, ok := Unwrap(r).(*Expression)
+	if !ok {
+		return r, nil
+	}
+
+	var excepted []Rule
+	operands := make([]Rule, 0, len(expression.GetOperands()))
+	for _, operand := range expression.GetOperands() {
+		exceptedOperand, exceptedRules := Except(operand, names)
+		excepted = append(excepted, exceptedRules...)
+
+		switch {
+		case exceptedOperand == nil && !expression.all:
+			return nil, excepted
+		case exceptedOperand != nil:
+			operands = append(operands, exceptedOperand)
+		}
+	}
+
+	if len(excepted) == 0 {
+		return r, nil
+	}
+
+	if len(operands) == 0 {
+		return nil, excepted
+	}
+
+	var c Rule = NewOr(operands)
+	if expression.all {
+		c = NewAnd(operands)
+	}
+
+	if annotated, ok := r.(*Annotated); ok {
+		return annotated.With(c), excepted
+	}
+
+	return c, excepted
+}
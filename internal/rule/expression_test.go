@@ -0,0 +1,120 @@
+package rule
+
+import (
+	"testing"
+)
+
+func TestExpression_Evaluate(t *testing.T) {
+	length := new(Length).Init()
+	if err := length.SetParameters([]string{"1-8"}); err != nil {
+		t.Fatal(err)
+	}
+
+	negated := new(Regex).Init()
+	if err := negated.SetParameters([]string{"!test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	prefix := new(Prefix).Init()
+	if err := prefix.SetParameters([]string{"use"}); err != nil {
+		t.Fatal(err)
+	}
+
+	kebab := RulesIndex["kebabcase"]
+	camel := RulesIndex["camelcase"]
+
+	tests := []*struct {
+		rule     *Expression
+		value    string
+		expected bool
+		message  string
+	}{
+		{rule: NewAnd([]Rule{kebab, length, negated}), value: "my-file", expected: true, message: ""},
+		{rule: NewAnd([]Rule{kebab, length, negated}), value: "my-long-file", expected: false, message: "length:1-8"},
+		{rule: NewAnd([]Rule{kebab, length, negated}), value: "MyFile", expected: false, message: "expected my-file.ts (kebab-case)"},
+		{rule: NewAnd([]Rule{kebab, length, negated}), value: "test", expected: false, message: "regex:!test"},
+		{rule: NewAnd([]Rule{camel, length}), value: "my_long_file", expected: false, message: "expected myLongFile.ts (camelCase) & length:1-8"},
+		{rule: NewAnd([]Rule{NewOr([]Rule{kebab, camel}), length}), value: "my_file", expected: false, message: "(expected my-file.ts (kebab-case) | expected myFile.ts (camelCase))"},
+		{rule: NewAnd([]Rule{NewOr([]Rule{kebab, camel}), length}), value: "myFile", expected: true, message: ""},
+		{rule: NewAnd([]Rule{camel, prefix}), value: "state", expected: false, message: "missing prefix `use`"},
+	}
+
+	i := 0
+	for _, test := range tests {
+		res, err := test.rule.Validate(test.value, "", true)
+		if err != nil {
+			t.Errorf("Test %d failed with unmatched error - %s", i, err.Error())
+			return
+		}
+
+		if res != test.expected {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, res)
+			return
+		}
+
+		if test.expected {
+			i++
+			continue
+		}
+
+		message := test.rule.ExplainWith(func(Rule) string { return test.value }, func(Rule) string { return ".ts" }, "").GetErrorMessage()
+		if message != test.message {
+			t.Errorf("Test %d failed with unmatched message - %s", i, message)
+			return
+		}
+
+		i++
+	}
+}
+
+func TestExpression_GetErrorMessage(t *testing.T) {
+	expression := NewOr([]Rule{
+		RulesIndex["snakecase"],
+		NewAnd([]Rule{NewOr([]Rule{RulesIndex["kebabcase"], RulesIndex["camelcase"]}), RulesIndex["lowercase"]}),
+	})
+
+	if message := expression.GetErrorMessage(); message != "snakecase | (kebabcase | camelcase) & lowercase" {
+		t.Errorf("Test failed with unmatched return value - %s", message)
+	}
+}
+
+func TestExcept(t *testing.T) {
+	length := new(Length).Init()
+	if err := length.SetParameters([]string{"1-8"}); err != nil {
+		t.Fatal(err)
+	}
+
+	kebab := RulesIndex["kebabcase"]
+	camel := RulesIndex["camelcase"]
+	names := map[string]bool{"kebabcase": true}
+
+	tests := []*struct {
+		rule     Rule
+		expected string
+		excepted int
+	}{
+		{rule: kebab, expected: "", excepted: 1},
+		{rule: camel, expected: "camelcase", excepted: 0},
+		{rule: NewAnd([]Rule{kebab, length}), expected: "length:1-8", excepted: 1},
+		{rule: NewAnd([]Rule{NewOr([]Rule{kebab, camel}), length}), expected: "length:1-8", excepted: 1},
+		{rule: NewAnd([]Rule{NewOr([]Rule{camel, length}), kebab}), expected: "(camelcase | length:1-8)", excepted: 1},
+		{rule: NewOr([]Rule{camel, kebab}), expected: "", excepted: 1},
+		{rule: NewAnnotated(NewAnd([]Rule{kebab, length}), "", SeverityWarning), expected: "length:1-8", excepted: 1},
+	}
+
+	i := 0
+	for _, test := range tests {
+		res, excepted := Except(test.rule, names)
+
+		var message string
+		if res != nil {
+			message = res.GetErrorMessage()
+		}
+
+		if message != test.expected || len(excepted) != test.excepted {
+			t.Errorf("Test %d failed with unmatched return value - %s (%d)", i, message, len(excepted))
+		}
+
+		i++
+	}
+}
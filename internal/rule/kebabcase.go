@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"strings"
 	"sync"
 	"unicode"
 )
@@ -61,6 +62,18 @@ func (rule *KebabCase) Validate(value string, _ string, _ bool) (bool, error) {
 	return true, nil
 }
 
+// Suggest converts the value to kebab case
+func (rule *KebabCase) Suggest(value string) (string, bool) {
+	return suggest(rule, joinWords(splitWords(value), "-", func(_ int, word string) string {
+		return strings.ToLower(word)
+	}))
+}
+
+// ExplainName explains the failure with the expected kebab case name
+func (rule *KebabCase) ExplainName(value string, rest string) Rule {
+	return expect(rule, "kebab-case", value, rest)
+}
+
 func (rule *KebabCase) GetErrorMessage() string {
 	return rule.GetName()
 }
@@ -0,0 +1,159 @@
+package rule
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const lengthExt = "ext"
+
+// BasenameRule is implemented by rules which validate the basename including all extensions
+type BasenameRule interface {
+	UseBasename() bool
+}
+
+type Length struct {
+	name      string
+	exclusive bool
+	min       uint16
+	max       uint16
+	ext       bool
+	*sync.RWMutex
+}
+
+func (rule *Length) Init() Rule {
+	rule.name = "length"
+	rule.exclusive = false
+	rule.RWMutex = new(sync.RWMutex)
+
+	return rule
+}
+
+func (rule *Length) GetName() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.name
+}
+
+// 0 = range (1 or 1-4) - optionally followed by :ext to count the extensions, too
+func (rule *Length) SetParameters(params []string) error {
+	rule.Lock()
+	defer rule.Unlock()
+
+	// length
+	if len(params) == 0 {
+		return fmt.Errorf("length value not exists")
+	}
+
+	// length:
+	if params[0] == "" {
+		return fmt.Errorf("length value is empty")
+	}
+
+	// length:1-4:ext
+	value, ext, withExt := strings.Cut(params[0], ":")
+	if withExt && ext != lengthExt {
+		return fmt.Errorf("length option %s not exists", ext)
+	}
+
+	minValue, maxValue, err := parseRange(value)
+	if err != nil {
+		return err
+	}
+
+	rule.min = minValue
+	rule.max = maxValue
+	rule.ext = withExt
+	return nil
+}
+
+// SetOptions sets the min and max options - ext: true counts the extensions, too
+func (rule *Length) SetOptions(options Options) error {
+	if err := options.only("min", "max", lengthExt); err != nil {
+		return err
+	}
+
+	minValue, withMin := options["min"]
+	maxValue, withMax := options["max"]
+	switch {
+	case !withMin && !withMax:
+		return fmt.Errorf("length option min or max not exists")
+	case !withMin:
+		minValue = "0"
+	case !withMax:
+		maxValue = fmt.Sprintf("%d", math.MaxInt16)
+	}
+
+	value := fmt.Sprintf("%s-%s", minValue, maxValue)
+
+	ext, err := options.bool(lengthExt)
+	if err != nil {
+		return err
+	}
+
+	if ext {
+		value = fmt.Sprintf("%s:%s", value, lengthExt)
+	}
+
+	return rule.SetParameters([]string{value})
+}
+
+func (rule *Length) GetParameters() []string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	value := fmt.Sprintf("%d-%d", rule.min, rule.max)
+	if rule.min == rule.max {
+		value = fmt.Sprintf("%d", rule.min)
+	}
+
+	if rule.ext {
+		return []string{fmt.Sprintf("%s:%s", value, lengthExt)}
+	}
+
+	return []string{value}
+}
+
+func (rule *Length) GetExclusive() bool {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.exclusive
+}
+
+func (rule *Length) UseBasename() bool {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.ext
+}
+
+// Validate checks if the number of characters is in range
+func (rule *Length) Validate(value string, _ string, _ bool) (bool, error) {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	length := utf8.RuneCountInString(value)
+	return length >= int(rule.min) && length <= int(rule.max), nil
+}
+
+func (rule *Length) GetErrorMessage() string {
+	return fmt.Sprintf("%s:%s", rule.GetName(), rule.GetParameters()[0])
+}
+
+func (rule *Length) Copy() Rule {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	c := new(Length)
+	c.Init()
+	c.min = rule.min
+	c.max = rule.max
+	c.ext = rule.ext
+
+	return c
+}
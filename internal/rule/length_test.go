@@ -0,0 +1,89 @@
+package rule
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestLength_GetParameters(t *testing.T) {
+	tests := []*struct {
+		params   []string
+		expected []string
+		ext      bool
+		err      error
+	}{
+		{params: []string{"30"}, expected: []string{"30"}, ext: false, err: nil},
+		{params: []string{"1-30"}, expected: []string{"1-30"}, ext: false, err: nil},
+		{params: []string{"1-30:ext"}, expected: []string{"1-30:ext"}, ext: true, err: nil},
+		{params: []string{"30-1"}, expected: []string{"0"}, ext: false, err: ErrInvalidRange},
+		{params: []string{"a-30"}, expected: []string{"0"}, ext: false, err: strconv.ErrSyntax},
+		{params: []string{"1-99999"}, expected: []string{"0"}, ext: false, err: strconv.ErrRange},
+	}
+
+	i := 0
+	for _, test := range tests {
+		rule := new(Length).Init().(*Length)
+
+		err := rule.SetParameters(test.params)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Test %d failed with unmatched error - %e", i, err)
+			return
+		}
+
+		params := rule.GetParameters()
+		if !reflect.DeepEqual(params, test.expected) {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, params)
+			return
+		}
+
+		if rule.UseBasename() != test.ext {
+			t.Errorf("Test %d failed with unmatched basename value - %+v", i, rule.UseBasename())
+			return
+		}
+
+		i++
+	}
+
+	for _, params := range [][]string{{}, {""}, {"1-30:unknown"}} {
+		if err := new(Length).Init().SetParameters(params); err == nil {
+			t.Errorf("Test %+v failed without error", params)
+		}
+	}
+}
+
+func TestLength_Validate(t *testing.T) {
+	tests := []*struct {
+		params   []string
+		value    string
+		expected bool
+	}{
+		{params: []string{"1-5"}, value: "hello", expected: true},
+		{params: []string{"1-5"}, value: "hello!", expected: false},
+		{params: []string{"1-5"}, value: "", expected: false},
+		{params: []string{"3"}, value: "äöü", expected: true},
+		{params: []string{"3-64"}, value: "ab", expected: false},
+	}
+
+	i := 0
+	for _, test := range tests {
+		rule := new(Length).Init()
+		if err := rule.SetParameters(test.params); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := rule.Validate(test.value, "", true)
+		if err != nil {
+			t.Errorf("Test %d failed with unmatched error - %s", i, err.Error())
+			return
+		}
+
+		if res != test.expected {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, res)
+			return
+		}
+
+		i++
+	}
+}
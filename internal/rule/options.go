@@ -0,0 +1,226 @@
+package rule
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Severities are ordered from the highest to the lowest severity
+var Severities = []string{SeverityError, SeverityWarning, SeverityInfo}
+
+// SeverityOf returns the severity of the rule - rules without severity are errors
+func SeverityOf(r Rule) string {
+	if annotated, ok := r.(*Annotated); ok && annotated.GetSeverity() != "" {
+		return annotated.GetSeverity()
+	}
+
+	return SeverityError
+}
+
+// HigherSeverity returns true if severity a is higher than severity b
+func HigherSeverity(a string, b string) bool {
+	return slices.Index(Severities, a) < slices.Index(Severities, b)
+}
+
+// Options are the named options of a rule object of the structured rule syntax
+//
+//	.ts:
+//	  - rule: regex
+//	    pattern: ^[a-z]+$
+type Options map[string]string
+
+// OptionsSetter is implemented by rules which accept named options
+// the options are converted to the parameters of the rule string syntax
+type OptionsSetter interface {
+	SetOptions(options Options) error
+}
+
+// only returns an error for the first option which is not one of the keys
+func (options Options) only(keys ...string) error {
+	for key := range options {
+		if !slices.Contains(keys, key) {
+			return fmt.Errorf("option %s not exists", key)
+		}
+	}
+
+	return nil
+}
+
+// bool returns the boolean value of the option - false if not set
+func (options Options) bool(key string) (bool, error) {
+	value, ok := options[key]
+	if !ok {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("option %s must be a boolean", key)
+	}
+
+	return b, nil
+}
+
+// Annotated is a rule with a custom error message or severity of a rule object
+// the help message and url of the rule set are added to every rule of the set
+// optional interfaces are passed through to the annotated rule
+type Annotated struct {
+	rule     Rule
+	message  string
+	severity string
+	help     string
+	url      string
+	*sync.RWMutex
+}
+
+func NewAnnotated(r Rule, message string, severity string) *Annotated {
+	annotated := &Annotated{rule: r, message: message, severity: severity}
+	annotated.Init()
+
+	return annotated
+}
+
+// Document returns the rule with the help message and url of its rule set
+func Document(r Rule, help string, url string) Rule {
+	annotated, ok := r.(*Annotated)
+	if !ok {
+		annotated = NewAnnotated(r, "", "")
+	}
+
+	c := annotated.Copy().(*Annotated)
+	c.help = help
+	c.url = url
+
+	return c
+}
+
+func (rule *Annotated) Init() Rule {
+	rule.RWMutex = new(sync.RWMutex)
+
+	return rule
+}
+
+// Unwrap returns the annotated rule
+func (rule *Annotated) Unwrap() Rule {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.rule
+}
+
+func (rule *Annotated) GetName() string {
+	return rule.Unwrap().GetName()
+}
+
+func (rule *Annotated) SetParameters(params []string) error {
+	return rule.Unwrap().SetParameters(params)
+}
+
+func (rule *Annotated) GetParameters() []string {
+	return rule.Unwrap().GetParameters()
+}
+
+func (rule *Annotated) GetExclusive() bool {
+	return rule.Unwrap().GetExclusive()
+}
+
+func (rule *Annotated) GetSeverity() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.severity
+}
+
+func (rule *Annotated) GetHelp() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.help
+}
+
+func (rule *Annotated) GetURL() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.url
+}
+
+func (rule *Annotated) Validate(value string, path string, fail bool) (bool, error) {
+	return rule.Unwrap().Validate(value, path, fail)
+}
+
+func (rule *Annotated) UseBasename() bool {
+	basenameRule, ok := rule.Unwrap().(BasenameRule)
+	return ok && basenameRule.UseBasename()
+}
+
+func (rule *Annotated) Suggest(value string) (string, bool) {
+	suggester, ok := rule.Unwrap().(Suggester)
+	if !ok {
+		return "", false
+	}
+
+	return suggester.Suggest(value)
+}
+
+// ExplainName explains the annotated rule - custom messages are kept
+func (rule *Annotated) ExplainName(value string, rest string) Rule {
+	explained := ExplainRule(rule.Unwrap(), value, rest)
+	if explained == rule.Unwrap() {
+		return rule
+	}
+
+	return rule.With(explained)
+}
+
+// With returns the rule with the annotations of this rule
+func (rule *Annotated) With(r Rule) Rule {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.copyTo(r)
+}
+
+func (rule *Annotated) GetErrorMessage() string {
+	rule.RLock()
+	message := rule.message
+	rule.RUnlock()
+
+	if message != "" {
+		return message
+	}
+
+	return rule.Unwrap().GetErrorMessage()
+}
+
+func (rule *Annotated) Copy() Rule {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.copyTo(rule.rule.Copy())
+}
+
+func (rule *Annotated) copyTo(r Rule) *Annotated {
+	c := NewAnnotated(r, rule.message, rule.severity)
+	c.help = rule.help
+	c.url = rule.url
+
+	return c
+}
+
+// Unwrap returns the rule without annotations
+func Unwrap(r Rule) Rule {
+	if annotated, ok := r.(*Annotated); ok {
+		return annotated.Unwrap()
+	}
+
+	return r
+}
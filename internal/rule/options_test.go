@@ -0,0 +1,90 @@
+package rule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOptionsSetter_SetOptions(t *testing.T) {
+	tests := []*struct {
+		rule     Rule
+		options  Options
+		expected []string
+	}{
+		{rule: new(Regex).Init(), options: Options{"pattern": "^[a-z]+ | [0-9]+$"}, expected: []string{"^[a-z]+ | [0-9]+$"}},
+		{rule: new(Regex).Init(), options: Options{"pattern": "test", "negate": "true"}, expected: []string{"!test"}},
+		{rule: new(Exists).Init(), options: Options{}, expected: []string{"1-32767"}},
+		{rule: new(Exists).Init(), options: Options{"min": "1", "max": "4"}, expected: []string{"1-4"}},
+		{rule: new(Exists).Init(), options: Options{"max": "1"}, expected: []string{"0-1"}},
+		{rule: new(Length).Init(), options: Options{"min": "3", "max": "30", "ext": "true"}, expected: []string{"3-30:ext"}},
+		{rule: new(Length).Init(), options: Options{"max": "30"}, expected: []string{"0-30"}},
+		{rule: new(Prefix).Init(), options: Options{"text": "use", "remainder": "PascalCase"}, expected: []string{"use+PascalCase"}},
+		{rule: new(Portable).Init(), options: Options{"checks": "windows,trailing"}, expected: []string{"windows,trailing"}},
+	}
+
+	i := 0
+	for _, test := range tests {
+		if err := test.rule.(OptionsSetter).SetOptions(test.options); err != nil {
+			t.Errorf("Test %d failed with unmatched error - %s", i, err.Error())
+			return
+		}
+
+		params := test.rule.GetParameters()
+		if !reflect.DeepEqual(params, test.expected) {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, params)
+			return
+		}
+
+		i++
+	}
+
+	failures := []*struct {
+		rule    Rule
+		options Options
+	}{
+		{rule: new(Regex).Init(), options: Options{"patern": "test"}},
+		{rule: new(Regex).Init(), options: Options{"pattern": "test", "negate": "maybe"}},
+		{rule: new(Exists).Init(), options: Options{"min": "4", "max": "1"}},
+		{rule: new(Length).Init(), options: Options{}},
+		{rule: new(Prefix).Init(), options: Options{"remainder": "PascalCase"}},
+		{rule: new(Portable).Init(), options: Options{"checks": "unix"}},
+	}
+
+	for _, failure := range failures {
+		if err := failure.rule.(OptionsSetter).SetOptions(failure.options); err == nil {
+			t.Errorf("Test %+v failed without error", failure.options)
+		}
+	}
+}
+
+func TestAnnotated(t *testing.T) {
+	prefix := new(Prefix).Init()
+	if err := prefix.SetParameters([]string{"use"}); err != nil {
+		t.Fatal(err)
+	}
+
+	annotated := NewAnnotated(prefix, "hooks must start with use", SeverityWarning)
+	if annotated.GetName() != "prefix" || annotated.GetSeverity() != SeverityWarning {
+		t.Errorf("Test failed with unmatched rule - %s %s", annotated.GetName(), annotated.GetSeverity())
+	}
+
+	if message := annotated.ExplainName("state", ".ts").GetErrorMessage(); message != "hooks must start with use" {
+		t.Errorf("Test failed with unmatched message - %s", message)
+	}
+
+	if message := NewAnnotated(prefix, "", SeverityInfo).ExplainName("state", ".ts").GetErrorMessage(); message != "missing prefix `use`" {
+		t.Errorf("Test failed with unmatched message - %s", message)
+	}
+
+	if suggestion, ok := annotated.Suggest("state"); !ok || suggestion != "usestate" {
+		t.Errorf("Test failed with unmatched suggestion - %s", suggestion)
+	}
+
+	if _, ok := NewAnnotated(new(Exists).Init(), "", SeverityInfo).Suggest("state"); ok {
+		t.Errorf("Test failed with suggestion of exists rule")
+	}
+
+	if Unwrap(annotated) != prefix || Unwrap(prefix) != prefix {
+		t.Errorf("Test failed with unmatched unwrapped rule")
+	}
+}
@@ -45,7 +45,8 @@ func (rule *PascalCase) GetExclusive() bool {
 // false if rune is no letter and no digit
 // false if first rune is not upper
 func (rule *PascalCase) Validate(value string, _ string, _ bool) (bool, error) {
-	for i, c := range value {
+	runes := []rune(value)
+	for i, c := range runes {
 		// must be letter or digit
 		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
 			return false, nil
@@ -62,17 +63,17 @@ func (rule *PascalCase) Validate(value string, _ string, _ bool) (bool, error) {
 			}
 
 			// rune -1 can be digit
-			if unicode.IsDigit(rune(value[i-1])) {
+			if unicode.IsDigit(runes[i-1]) {
 				continue
 			}
 
 			// allow cases like SsrVFor.ts
-			if i >= 2 && unicode.IsUpper(rune(value[i-1])) && unicode.IsLower(rune(value[i-2])) {
+			if i >= 2 && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i-2]) {
 				continue
 			}
 
 			// rune -1 must be lower
-			if !unicode.IsLower(rune(value[i-1])) {
+			if !unicode.IsLower(runes[i-1]) {
 				return false, nil
 			}
 		}
@@ -81,6 +82,18 @@ func (rule *PascalCase) Validate(value string, _ string, _ bool) (bool, error) {
 	return true, nil
 }
 
+// Suggest converts the value to pascal case
+func (rule *PascalCase) Suggest(value string) (string, bool) {
+	return suggest(rule, joinWords(splitWords(value), "", func(_ int, word string) string {
+		return title(word)
+	}))
+}
+
+// ExplainName explains the failure with the expected pascal case name
+func (rule *PascalCase) ExplainName(value string, rest string) Rule {
+	return expect(rule, "PascalCase", value, rest)
+}
+
 func (rule *PascalCase) GetErrorMessage() string {
 	return rule.GetName()
 }
@@ -0,0 +1,200 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	portablePosix    = "posix"
+	portableWindows  = "windows"
+	portableTrailing = "trailing"
+)
+
+// windowsReserved are device names which can't be used as names on windows - even with extensions
+var windowsReserved = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+type Portable struct {
+	name      string
+	exclusive bool
+	posix     bool
+	windows   bool
+	trailing  bool
+	*sync.RWMutex
+}
+
+func (rule *Portable) Init() Rule {
+	rule.name = "portable"
+	rule.exclusive = false
+	rule.posix = true
+	rule.windows = true
+	rule.trailing = true
+	rule.RWMutex = new(sync.RWMutex)
+
+	return rule
+}
+
+func (rule *Portable) GetName() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.name
+}
+
+// 0 = comma separated checks (posix, windows, trailing) - all checks without parameters
+func (rule *Portable) SetParameters(params []string) error {
+	rule.Lock()
+	defer rule.Unlock()
+
+	// portable
+	if len(params) == 0 {
+		rule.posix, rule.windows, rule.trailing = true, true, true
+		return nil
+	}
+
+	// portable:windows,trailing
+	rule.posix, rule.windows, rule.trailing = false, false, false
+	for _, check := range strings.Split(params[0], ",") {
+		switch strings.TrimSpace(check) {
+		case portablePosix:
+			rule.posix = true
+		case portableWindows:
+			rule.windows = true
+		case portableTrailing:
+			rule.trailing = true
+		default:
+			return fmt.Errorf("portable check %s not exists", check)
+		}
+	}
+
+	return nil
+}
+
+// SetOptions sets the comma separated checks option
+func (rule *Portable) SetOptions(options Options) error {
+	if err := options.only("checks"); err != nil {
+		return err
+	}
+
+	checks, ok := options["checks"]
+	if !ok {
+		return rule.SetParameters([]string{})
+	}
+
+	return rule.SetParameters([]string{checks})
+}
+
+func (rule *Portable) GetParameters() []string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	if rule.posix && rule.windows && rule.trailing {
+		return []string{}
+	}
+
+	checks := make([]string, 0, 3)
+	if rule.posix {
+		checks = append(checks, portablePosix)
+	}
+
+	if rule.windows {
+		checks = append(checks, portableWindows)
+	}
+
+	if rule.trailing {
+		checks = append(checks, portableTrailing)
+	}
+
+	return []string{strings.Join(checks, ",")}
+}
+
+func (rule *Portable) GetExclusive() bool {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.exclusive
+}
+
+// UseBasename validates the basename - windows reserved names and trailing dots include the extensions
+func (rule *Portable) UseBasename() bool {
+	return true
+}
+
+// Validate checks the enabled checks
+//   - posix: only A-Z a-z 0-9 . _ - and no leading -
+//   - windows: no reserved names (CON, NUL, COM1, ...), no < > : " / \ | ? * and control characters
+//   - trailing: no trailing spaces or dots
+func (rule *Portable) Validate(value string, _ string, _ bool) (bool, error) {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	if rule.posix && !isPosixPortable(value) {
+		return false, nil
+	}
+
+	if rule.windows && !isWindowsPortable(value) {
+		return false, nil
+	}
+
+	if rule.trailing && strings.TrimRight(value, " .") != value {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func isPosixPortable(value string) bool {
+	if strings.HasPrefix(value, "-") {
+		return false
+	}
+
+	for _, c := range value {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '.', c == '_', c == '-':
+			continue
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isWindowsPortable(value string) bool {
+	if strings.ContainsFunc(value, func(c rune) bool {
+		return c < 32 || strings.ContainsRune(`<>:"/\|?*`, c)
+	}) {
+		return false
+	}
+
+	stem, _, _ := strings.Cut(value, ".")
+	_, reserved := windowsReserved[strings.ToUpper(strings.TrimRight(stem, " "))]
+
+	return !reserved
+}
+
+func (rule *Portable) GetErrorMessage() string {
+	if parameters := rule.GetParameters(); len(parameters) > 0 {
+		return fmt.Sprintf("%s:%s", rule.GetName(), parameters[0])
+	}
+
+	return rule.GetName()
+}
+
+func (rule *Portable) Copy() Rule {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	c := new(Portable)
+	c.Init()
+	c.posix = rule.posix
+	c.windows = rule.windows
+	c.trailing = rule.trailing
+
+	return c
+}
@@ -0,0 +1,90 @@
+package rule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPortable_GetParameters(t *testing.T) {
+	tests := []*struct {
+		params   []string
+		expected []string
+	}{
+		{params: []string{}, expected: []string{}},
+		{params: []string{"posix"}, expected: []string{"posix"}},
+		{params: []string{"trailing,windows"}, expected: []string{"windows,trailing"}},
+		{params: []string{"posix,windows,trailing"}, expected: []string{}},
+	}
+
+	i := 0
+	for _, test := range tests {
+		rule := new(Portable).Init()
+		if err := rule.SetParameters(test.params); err != nil {
+			t.Errorf("Test %d failed with unmatched error - %s", i, err.Error())
+			return
+		}
+
+		params := rule.GetParameters()
+		if !reflect.DeepEqual(params, test.expected) {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, params)
+			return
+		}
+
+		i++
+	}
+
+	for _, params := range [][]string{{""}, {"unknown"}, {"posix,"}} {
+		if err := new(Portable).Init().SetParameters(params); err == nil {
+			t.Errorf("Test %+v failed without error", params)
+		}
+	}
+}
+
+func TestPortable_Validate(t *testing.T) {
+	tests := []*struct {
+		params   []string
+		value    string
+		expected bool
+	}{
+		{params: []string{}, value: "my_file-1.test.ts", expected: true},
+		{params: []string{}, value: "my file.ts", expected: false},
+		{params: []string{}, value: "-file.ts", expected: false},
+		{params: []string{}, value: "file.", expected: false},
+		{params: []string{"posix"}, value: "äöü.ts", expected: false},
+		{params: []string{"posix"}, value: "file.", expected: true},
+		{params: []string{"windows"}, value: "my file.ts", expected: true},
+		{params: []string{"windows"}, value: "a:b.ts", expected: false},
+		{params: []string{"windows"}, value: "a?.ts", expected: false},
+		{params: []string{"windows"}, value: "a\tb.ts", expected: false},
+		{params: []string{"windows"}, value: "CON", expected: false},
+		{params: []string{"windows"}, value: "nul.tar.gz", expected: false},
+		{params: []string{"windows"}, value: "Com1.ts", expected: false},
+		{params: []string{"windows"}, value: "console.ts", expected: true},
+		{params: []string{"windows"}, value: "com10.ts", expected: true},
+		{params: []string{"trailing"}, value: "file ", expected: false},
+		{params: []string{"trailing"}, value: "file.ts.", expected: false},
+		{params: []string{"trailing"}, value: ".gitignore", expected: true},
+		{params: []string{"trailing"}, value: "CON", expected: true},
+	}
+
+	i := 0
+	for _, test := range tests {
+		rule := new(Portable).Init()
+		if err := rule.SetParameters(test.params); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := rule.Validate(test.value, "", true)
+		if err != nil {
+			t.Errorf("Test %d failed with unmatched error - %s", i, err.Error())
+			return
+		}
+
+		if res != test.expected {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, res)
+			return
+		}
+
+		i++
+	}
+}
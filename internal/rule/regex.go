@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -9,6 +10,13 @@ import (
 
 const negate = '!'
 
+var (
+	ErrInvalidPattern = errors.New("invalid regex pattern")
+
+	// placeholders are replaced by the parent dirs before matching
+	placeholders = regexp.MustCompile(`\$\{[0-9]+\}`)
+)
+
 type Regex struct {
 	name         string
 	exclusive    bool
@@ -45,17 +53,39 @@ func (rule *Regex) SetParameters(params []string) error {
 		return fmt.Errorf("regex pattern is empty")
 	}
 
-	if params[0][0] == negate {
-		rule.negate = true
-		rule.regexPattern = params[0][1:]
-		return nil
+	negated, regexPattern := false, params[0]
+	if regexPattern[0] == negate {
+		negated, regexPattern = true, regexPattern[1:]
 	}
 
-	rule.negate = false
-	rule.regexPattern = params[0]
+	if _, err := regexp.Compile(placeholders.ReplaceAllString(regexPattern, "")); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPattern, err.Error())
+	}
+
+	rule.negate = negated
+	rule.regexPattern = regexPattern
 	return nil
 }
 
+// SetOptions sets the pattern option - negate: true inverts the match
+func (rule *Regex) SetOptions(options Options) error {
+	if err := options.only("pattern", "negate"); err != nil {
+		return err
+	}
+
+	negated, err := options.bool("negate")
+	if err != nil {
+		return err
+	}
+
+	pattern := options["pattern"]
+	if negated {
+		pattern = string(negate) + pattern
+	}
+
+	return rule.SetParameters([]string{pattern})
+}
+
 func (rule *Regex) GetParameters() []string {
 	if rule.negate {
 		return []string{string(negate) + rule.regexPattern}
@@ -22,6 +22,8 @@ func TestRegex(t *testing.T) {
 		{params: []string{"${1}_${0}"}, value: "google_test", path: "google/test", expected: true, err: nil},
 		{params: []string{"${1}"}, value: "swu1", path: "gen/swu1/data", expected: true, err: nil}, // github.com/loeffel-io/ls-lint/issues/307
 		{params: []string{"${1}_${0}"}, value: "test", path: "google/test", expected: false, err: nil},
+		{params: []string{"[a-z"}, value: "test", path: "", expected: false, err: ErrInvalidPattern},
+		{params: []string{"!(a"}, value: "test", path: "", expected: false, err: ErrInvalidPattern},
 	}
 
 	i := 0
@@ -4,6 +4,12 @@ var RulesIndex = map[string]Rule{
 	"lowercase": new(Lowercase).Init(),
 	"regex":     new(Regex).Init(),
 	"exists":    new(Exists).Init(),
+	"length":    new(Length).Init(),
+	"portable":  new(Portable).Init(),
+	"prefix":    new(Prefix).Init(),
+	"suffix":    new(Suffix).Init(),
+
+	"unique-casefold": new(UniqueCasefold).Init(),
 
 	"camelcase":          new(CamelCase).Init(),
 	"pascalcase":         new(PascalCase).Init(),
@@ -16,6 +22,12 @@ var Rules = map[string]Rule{
 	"lowercase": RulesIndex["lowercase"],
 	"regex":     RulesIndex["regex"],
 	"exists":    RulesIndex["exists"],
+	"length":    RulesIndex["length"],
+	"portable":  RulesIndex["portable"],
+	"prefix":    RulesIndex["prefix"],
+	"suffix":    RulesIndex["suffix"],
+
+	"unique-casefold": RulesIndex["unique-casefold"],
 
 	"camelcase": RulesIndex["camelcase"],
 	"camelCase": RulesIndex["camelcase"],
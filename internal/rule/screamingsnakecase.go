@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"strings"
 	"sync"
 	"unicode"
 )
@@ -61,6 +62,18 @@ func (rule *ScreamingSnakeCase) Validate(value string, _ string, _ bool) (bool,
 	return true, nil
 }
 
+// Suggest converts the value to screaming snake case
+func (rule *ScreamingSnakeCase) Suggest(value string) (string, bool) {
+	return suggest(rule, joinWords(splitWords(value), "_", func(_ int, word string) string {
+		return strings.ToUpper(word)
+	}))
+}
+
+// ExplainName explains the failure with the expected screaming snake case name
+func (rule *ScreamingSnakeCase) ExplainName(value string, rest string) Rule {
+	return expect(rule, "SCREAMING_SNAKE_CASE", value, rest)
+}
+
 func (rule *ScreamingSnakeCase) GetErrorMessage() string {
 	return rule.GetName()
 }
@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"strings"
 	"sync"
 	"unicode"
 )
@@ -61,6 +62,18 @@ func (rule *SnakeCase) Validate(value string, _ string, _ bool) (bool, error) {
 	return true, nil
 }
 
+// Suggest converts the value to snake case
+func (rule *SnakeCase) Suggest(value string) (string, bool) {
+	return suggest(rule, joinWords(splitWords(value), "_", func(_ int, word string) string {
+		return strings.ToLower(word)
+	}))
+}
+
+// ExplainName explains the failure with the expected snake case name
+func (rule *SnakeCase) ExplainName(value string, rest string) Rule {
+	return expect(rule, "snake_case", value, rest)
+}
+
 func (rule *SnakeCase) GetErrorMessage() string {
 	return rule.GetName()
 }
@@ -0,0 +1,99 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+type UniqueCasefold struct {
+	name       string
+	exclusive  bool
+	collisions []string
+	*sync.RWMutex
+}
+
+func (rule *UniqueCasefold) Init() Rule {
+	rule.name = "unique-casefold"
+	rule.exclusive = true
+	rule.RWMutex = new(sync.RWMutex)
+
+	return rule
+}
+
+func (rule *UniqueCasefold) GetName() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.name
+}
+
+func (rule *UniqueCasefold) SetParameters(params []string) error {
+	return nil
+}
+
+// GetParameters returns the colliding names of reported errors
+func (rule *UniqueCasefold) GetParameters() []string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.collisions
+}
+
+func (rule *UniqueCasefold) GetExclusive() bool {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.exclusive
+}
+
+// Validate is always valid - the siblings of a dir are compared by the linter
+func (rule *UniqueCasefold) Validate(_ string, _ string, _ bool) (bool, error) {
+	return true, nil
+}
+
+// WithCollisions returns a copy of the rule which reports the colliding names
+func (rule *UniqueCasefold) WithCollisions(names []string) Rule {
+	c := new(UniqueCasefold)
+	c.Init()
+	c.collisions = names
+
+	return c
+}
+
+func (rule *UniqueCasefold) GetErrorMessage() string {
+	if len(rule.GetParameters()) == 0 {
+		return rule.GetName()
+	}
+
+	return fmt.Sprintf("%s (%s)", rule.GetName(), strings.Join(rule.GetParameters(), ", "))
+}
+
+func (rule *UniqueCasefold) Copy() Rule {
+	return rule
+}
+
+// Casefold returns the case-folded and NFC normalized name
+// canonically equivalent names (e.g. decomposed names created on macOS) fold to the same value
+// names with the same value collide on case-insensitive filesystems
+func Casefold(name string) string {
+	runes := []rune(norm.NFD.String(name))
+	for i, c := range runes {
+		runes[i] = fold(c)
+	}
+
+	return norm.NFC.String(string(runes))
+}
+
+// fold returns the smallest rune of the case folding orbit
+func fold(c rune) rune {
+	smallest := c
+	for f := unicode.SimpleFold(c); f != c; f = unicode.SimpleFold(f) {
+		smallest = min(smallest, f)
+	}
+
+	return smallest
+}
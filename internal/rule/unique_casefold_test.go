@@ -0,0 +1,48 @@
+package rule
+
+import (
+	"testing"
+)
+
+func TestCasefold(t *testing.T) {
+	tests := []*struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{a: "Button.tsx", b: "button.tsx", expected: true},
+		{a: "README.md", b: "readme.MD", expected: true},
+		{a: "caf\u00e9.ts", b: "Cafe\u0301.ts", expected: true},
+		{a: "Äpfel", b: "äpfel", expected: true},
+		{a: "Straſe", b: "strase", expected: true},
+		{a: "Kelvin", b: "kelvin", expected: true},
+		{a: "cafe.ts", b: "caf\u00e9.ts", expected: false},
+		{a: "button.ts", b: "button.tsx", expected: false},
+	}
+
+	i := 0
+	for _, test := range tests {
+		res := Casefold(test.a) == Casefold(test.b)
+		if res != test.expected {
+			t.Errorf("Test %d failed with unmatched return value - %+v", i, res)
+			return
+		}
+
+		i++
+	}
+}
+
+func TestUniqueCasefold_GetErrorMessage(t *testing.T) {
+	rule := new(UniqueCasefold).Init().(*UniqueCasefold)
+	if message := rule.GetErrorMessage(); message != "unique-casefold" {
+		t.Errorf("Test failed with unmatched return value - %s", message)
+	}
+
+	if message := rule.WithCollisions([]string{"Button.tsx", "button.tsx"}).GetErrorMessage(); message != "unique-casefold (Button.tsx, button.tsx)" {
+		t.Errorf("Test failed with unmatched return value - %s", message)
+	}
+
+	if rule.GetErrorMessage() != "unique-casefold" {
+		t.Errorf("Test failed with modified rule")
+	}
+}
@@ -0,0 +1,236 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Suggester is implemented by rules which can convert a value to a valid value
+type Suggester interface {
+	// Suggest returns the converted value and true if the converted value is valid
+	Suggest(value string) (string, bool)
+}
+
+// NameExplainer is implemented by rules which explain a failure with the expected name
+type NameExplainer interface {
+	// ExplainName returns a copy of the rule whose error message contains the expected name
+	// rest is the part of the name which is not validated by the rule (e.g. the extensions)
+	ExplainName(value string, rest string) Rule
+}
+
+// ExplainRule returns the rule explained by the expected name or the explanation of the value
+// the rule is returned unchanged if it can't explain its failure
+func ExplainRule(r Rule, value string, rest string) Rule {
+	switch explainer := r.(type) {
+	case NameExplainer:
+		return explainer.ExplainName(value, rest)
+	case Explainer:
+		return explainer.Explain(value)
+	default:
+		return r
+	}
+}
+
+// splitWords splits the value into words
+// words are separated by any rune which is no letter, mark or digit and by case changes:
+//   - fooBar => foo, Bar
+//   - foo1Bar => foo1, Bar
+//   - HTTPServer => HTTP, Server
+//   - ÜberGröße => Über, Größe
+//
+// digits and combining marks belong to the previous word
+func splitWords(value string) []string {
+	runes := []rune(value)
+	words := make([]string, 0)
+	word := make([]rune, 0, len(runes))
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+
+	for i, c := range runes {
+		if unicode.IsMark(c) && len(word) > 0 {
+			word = append(word, c)
+			continue
+		}
+
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			flush()
+			continue
+		}
+
+		if len(word) > 0 && isUpper(c) {
+			prev := lastBase(word)
+
+			switch {
+			case unicode.IsLower(prev) || unicode.IsDigit(prev):
+				flush()
+			case isUpper(prev) && unicode.IsLower(nextBase(runes, i)):
+				flush()
+			}
+		}
+
+		word = append(word, c)
+	}
+
+	flush()
+	return words
+}
+
+// isUpper returns true for upper and title case letters (e.g. ǅ)
+func isUpper(c rune) bool {
+	return unicode.IsUpper(c) || unicode.IsTitle(c)
+}
+
+// lastBase returns the last rune of the word which is no combining mark
+func lastBase(word []rune) rune {
+	for i := len(word) - 1; i >= 0; i-- {
+		if !unicode.IsMark(word[i]) {
+			return word[i]
+		}
+	}
+
+	return 0
+}
+
+// nextBase returns the next rune after i which is no combining mark
+func nextBase(runes []rune, i int) rune {
+	for _, c := range runes[i+1:] {
+		if !unicode.IsMark(c) {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// joinWords joins the words with the separator after applying the converter on each word
+func joinWords(words []string, sep string, converter func(i int, word string) string) string {
+	converted := make([]string, len(words))
+	for i, word := range words {
+		converted[i] = converter(i, word)
+	}
+
+	return strings.Join(converted, sep)
+}
+
+// title converts the first rune to title case and all other runes to lower
+func title(word string) string {
+	runes := []rune(strings.ToLower(word))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToTitle(runes[0])
+	}
+
+	return string(runes)
+}
+
+// suggest validates the suggestion with the rule
+func suggest(rule Rule, suggestion string) (string, bool) {
+	if suggestion == "" {
+		return "", false
+	}
+
+	valid, err := rule.Validate(suggestion, "", true)
+	if err != nil || !valid {
+		return "", false
+	}
+
+	return suggestion, true
+}
+
+// Expected is a failed rule whose error message is the expected name of the convention
+type Expected struct {
+	rule       Rule
+	convention string
+	name       string
+	*sync.RWMutex
+}
+
+// expect returns the rule explained by the suggestion of the value
+// the leading dots of hidden names (e.g. .git) are kept
+// the rule is returned unchanged if there is no suggestion or the suggestion is the value
+func expect(rule Rule, convention string, value string, rest string) Rule {
+	suggester, ok := rule.(Suggester)
+	if !ok {
+		return rule
+	}
+
+	name := strings.TrimLeft(value, ".")
+	suggestion, ok := suggester.Suggest(name)
+	if !ok || suggestion == name {
+		return rule
+	}
+
+	expected := &Expected{rule: rule, convention: convention, name: value[:len(value)-len(name)] + suggestion + rest}
+	expected.Init()
+
+	return expected
+}
+
+func (rule *Expected) Init() Rule {
+	rule.RWMutex = new(sync.RWMutex)
+
+	return rule
+}
+
+// Unwrap returns the explained rule
+func (rule *Expected) Unwrap() Rule {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.rule
+}
+
+// GetExpected returns the expected name
+func (rule *Expected) GetExpected() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return rule.name
+}
+
+func (rule *Expected) GetName() string {
+	return rule.Unwrap().GetName()
+}
+
+func (rule *Expected) SetParameters(params []string) error {
+	return rule.Unwrap().SetParameters(params)
+}
+
+func (rule *Expected) GetParameters() []string {
+	return rule.Unwrap().GetParameters()
+}
+
+func (rule *Expected) GetExclusive() bool {
+	return rule.Unwrap().GetExclusive()
+}
+
+func (rule *Expected) Validate(value string, path string, fail bool) (bool, error) {
+	return rule.Unwrap().Validate(value, path, fail)
+}
+
+func (rule *Expected) Suggest(value string) (string, bool) {
+	return rule.Unwrap().(Suggester).Suggest(value)
+}
+
+func (rule *Expected) GetErrorMessage() string {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	return fmt.Sprintf("expected %s (%s)", rule.name, rule.convention)
+}
+
+func (rule *Expected) Copy() Rule {
+	rule.RLock()
+	defer rule.RUnlock()
+
+	c := &Expected{rule: rule.rule.Copy(), convention: rule.convention, name: rule.name}
+	c.Init()
+
+	return c
+}
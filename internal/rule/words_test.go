@@ -0,0 +1,97 @@
+package rule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []*struct {
+		value    string
+		expected []string
+	}{
+		{value: "", expected: []string{}},
+		{value: "kebab-case", expected: []string{"kebab", "case"}},
+		{value: "snake_case_123", expected: []string{"snake", "case", "123"}},
+		{value: "camelCase", expected: []string{"camel", "Case"}},
+		{value: "PascalCase", expected: []string{"Pascal", "Case"}},
+		{value: "Pascal1Case", expected: []string{"Pascal1", "Case"}},
+		{value: "SCREAMING_SNAKE", expected: []string{"SCREAMING", "SNAKE"}},
+		{value: "HTTPServer", expected: []string{"HTTP", "Server"}},
+		{value: "myHTML5Parser", expected: []string{"my", "HTML5", "Parser"}},
+		{value: "My File  name", expected: []string{"My", "File", "name"}},
+		{value: "übergrößeÄnderung", expected: []string{"übergröße", "Änderung"}},
+		{value: "cafe\u0301Menu", expected: []string{"cafe\u0301", "Menu"}},
+		{value: "E\u0301TAT\u0301Ecole", expected: []string{"E\u0301TAT\u0301", "Ecole"}},
+		{value: "\u01c5emalBaba", expected: []string{"\u01c5emal", "Baba"}},
+		{value: "日本語-file", expected: []string{"日本語", "file"}},
+		{value: "--", expected: []string{}},
+	}
+
+	for i, test := range tests {
+		res := splitWords(test.value)
+
+		if !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("Test %d failed with unmatched return value - %#v", i, res)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []*struct {
+		rule     Suggester
+		value    string
+		expected string
+		valid    bool
+	}{
+		{rule: new(KebabCase).Init().(Suggester), value: "MyFile", expected: "my-file", valid: true},
+		{rule: new(KebabCase).Init().(Suggester), value: "HTTPServer_v2", expected: "http-server-v2", valid: true},
+		{rule: new(KebabCase).Init().(Suggester), value: "--", expected: "", valid: false},
+		{rule: new(SnakeCase).Init().(Suggester), value: "my-file", expected: "my_file", valid: true},
+		{rule: new(ScreamingSnakeCase).Init().(Suggester), value: "myFile", expected: "MY_FILE", valid: true},
+		{rule: new(CamelCase).Init().(Suggester), value: "HTTP-server", expected: "httpServer", valid: true},
+		{rule: new(CamelCase).Init().(Suggester), value: "my_file_2", expected: "myFile2", valid: true},
+		{rule: new(PascalCase).Init().(Suggester), value: "my-html5-parser", expected: "MyHtml5Parser", valid: true},
+		{rule: new(PascalCase).Init().(Suggester), value: "MY_FILE", expected: "MyFile", valid: true},
+		{rule: new(CamelCase).Init().(Suggester), value: "Über-größe", expected: "überGröße", valid: true},
+		{rule: new(PascalCase).Init().(Suggester), value: "\u01c6ungla", expected: "\u01c5ungla", valid: true},
+		{rule: new(KebabCase).Init().(Suggester), value: "日本語", expected: "", valid: false},
+	}
+
+	for i, test := range tests {
+		res, valid := test.rule.Suggest(test.value)
+
+		if res != test.expected || valid != test.valid {
+			t.Errorf("Test %d failed with unmatched return value - %s (%t)", i, res, valid)
+		}
+	}
+}
+
+func TestExplainRule(t *testing.T) {
+	tests := []*struct {
+		rule     Rule
+		value    string
+		rest     string
+		expected string
+	}{
+		{rule: new(KebabCase).Init(), value: "MyFile", rest: ".ts", expected: "expected my-file.ts (kebab-case)"},
+		{rule: new(CamelCase).Init(), value: "my_file", rest: ".d.ts", expected: "expected myFile.d.ts (camelCase)"},
+		{rule: new(PascalCase).Init(), value: "my-file", rest: "", expected: "expected MyFile (PascalCase)"},
+		{rule: new(SnakeCase).Init(), value: "myFile", rest: ".go", expected: "expected my_file.go (snake_case)"},
+		{rule: new(ScreamingSnakeCase).Init(), value: "myFile", rest: ".md", expected: "expected MY_FILE.md (SCREAMING_SNAKE_CASE)"},
+		{rule: new(KebabCase).Init(), value: "--", rest: ".ts", expected: "kebabcase"},
+		{rule: new(KebabCase).Init(), value: ".git", rest: "", expected: "kebabcase"},
+		{rule: new(KebabCase).Init(), value: ".My_Config", rest: "", expected: "expected .my-config (kebab-case)"},
+		{rule: new(Lowercase).Init(), value: "MyFile", rest: ".ts", expected: "lowercase"},
+		{rule: NewAnnotated(new(KebabCase).Init(), "", SeverityWarning), value: "MyFile", rest: ".ts", expected: "expected my-file.ts (kebab-case)"},
+		{rule: NewAnnotated(new(KebabCase).Init(), "use kebab-case", ""), value: "MyFile", rest: ".ts", expected: "use kebab-case"},
+	}
+
+	for i, test := range tests {
+		res := ExplainRule(test.rule, test.value, test.rest)
+
+		if res.GetErrorMessage() != test.expected || res.GetName() != test.rule.GetName() {
+			t.Errorf("Test %d failed with unmatched return value - %s", i, res.GetErrorMessage())
+		}
+	}
+}
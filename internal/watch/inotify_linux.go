@@ -0,0 +1,166 @@
+//go:build linux
+
+package watch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const mask = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_CLOSE_WRITE | syscall.IN_DELETE_SELF | syscall.IN_ONLYDIR
+
+// Watcher subscribes to inotify events of all not skipped dirs under the root
+type Watcher struct {
+	root    string
+	skip    SkipFunc
+	fd      int
+	file    *os.File
+	watches map[int32]string
+	*sync.Mutex
+}
+
+func New(root string, skip SkipFunc) (_ *Watcher, err error) {
+	var fd int
+	if fd, err = syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK); err != nil {
+		return nil, fmt.Errorf("inotify init failed with %w", err)
+	}
+
+	watcher := &Watcher{
+		root:    root,
+		skip:    skip,
+		fd:      fd,
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		watches: make(map[int32]string),
+		Mutex:   new(sync.Mutex),
+	}
+
+	if err = watcher.add("."); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	return watcher, nil
+}
+
+// add watches the dir and all of its not skipped subdirectories
+func (watcher *Watcher) add(dir string) error {
+	return fs.WalkDir(os.DirFS(watcher.root), dir, func(path string, info fs.DirEntry, err error) error {
+		if err != nil {
+			// removed before it was watched
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		if path != "." && watcher.skip != nil && watcher.skip(path, true) {
+			return fs.SkipDir
+		}
+
+		wd, err := syscall.InotifyAddWatch(watcher.fd, filepath.Join(watcher.root, path), mask)
+		if err != nil {
+			if errors.Is(err, syscall.ENOENT) {
+				return nil
+			}
+
+			return fmt.Errorf("inotify watch of %s failed with %w", path, err)
+		}
+
+		watcher.watches[int32(wd)] = path
+		return nil
+	})
+}
+
+// Next blocks until paths were created, removed, renamed or written
+// and returns them after no further events occurred for the debounce time
+func (watcher *Watcher) Next() (_ []string, err error) {
+	watcher.Lock()
+	defer watcher.Unlock()
+
+	changes := make(map[string]struct{})
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+
+	if err = watcher.file.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+
+	for {
+		var n int
+		if n, err = watcher.file.Read(buf); err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) && len(changes) > 0 {
+				return sorted(changes), nil
+			}
+
+			return nil, err
+		}
+
+		if err = watcher.parse(buf[:n], changes); err != nil {
+			return nil, err
+		}
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		if err = watcher.file.SetReadDeadline(time.Now().Add(Debounce)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (watcher *Watcher) parse(buf []byte, changes map[string]struct{}) error {
+	for offset := 0; offset+syscall.SizeofInotifyEvent <= len(buf); {
+		event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+		nameBytes := buf[offset+syscall.SizeofInotifyEvent : offset+syscall.SizeofInotifyEvent+int(event.Len)]
+		offset += syscall.SizeofInotifyEvent + int(event.Len)
+
+		if event.Mask&syscall.IN_Q_OVERFLOW != 0 {
+			changes[All] = struct{}{}
+			continue
+		}
+
+		dir, ok := watcher.watches[event.Wd]
+		if !ok {
+			continue
+		}
+
+		if event.Mask&(syscall.IN_DELETE_SELF|syscall.IN_IGNORED) != 0 {
+			delete(watcher.watches, event.Wd)
+			continue
+		}
+
+		name := string(bytes.TrimRight(nameBytes, "\x00"))
+		if name == "" {
+			continue
+		}
+
+		p := path.Join(dir, name)
+		changes[p] = struct{}{}
+
+		if event.Mask&syscall.IN_ISDIR != 0 && event.Mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 {
+			if err := watcher.add(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (watcher *Watcher) Close() error {
+	return watcher.file.Close()
+}
@@ -0,0 +1,94 @@
+//go:build linux
+
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWatcher_Next(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "node_modules"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := New(root, func(path string, _ bool) bool {
+		return path == "node_modules"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	var tests = []*struct {
+		description string
+		change      func() error
+		expected    []string
+	}{
+		{
+			description: "created file",
+			change: func() error {
+				return os.WriteFile(filepath.Join(root, "a.go"), nil, 0o644)
+			},
+			expected: []string{"a.go"},
+		},
+		{
+			description: "created dir",
+			change: func() error {
+				return os.Mkdir(filepath.Join(root, "src"), 0o755)
+			},
+			expected: []string{"src"},
+		},
+		{
+			description: "created file in created dir",
+			change: func() error {
+				return os.WriteFile(filepath.Join(root, "src", "b.go"), nil, 0o644)
+			},
+			expected: []string{"src/b.go"},
+		},
+		{
+			description: "renamed file",
+			change: func() error {
+				return os.Rename(filepath.Join(root, "src", "b.go"), filepath.Join(root, "src", "c.go"))
+			},
+			expected: []string{"src/b.go", "src/c.go"},
+		},
+		{
+			description: "skipped dir",
+			change: func() error {
+				if err := os.WriteFile(filepath.Join(root, "node_modules", "d.go"), nil, 0o644); err != nil {
+					return err
+				}
+
+				return os.Remove(filepath.Join(root, "a.go"))
+			},
+			expected: []string{"a.go"},
+		},
+	}
+
+	var i = 0
+	for _, test := range tests {
+		if err = test.change(); err != nil {
+			t.Fatal(err)
+		}
+
+		var res []string
+		if res, err = watcher.Next(); err != nil {
+			t.Errorf("Test %d (%s) failed with unmatched error value - %v", i, test.description, err)
+			return
+		}
+
+		if !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("Test %d (%s) failed with unmatched return value - %+v", i, test.description, res)
+			return
+		}
+
+		i++
+	}
+}
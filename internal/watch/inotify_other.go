@@ -0,0 +1,24 @@
+//go:build !linux
+
+package watch
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// Watcher is only supported on linux
+type Watcher struct{}
+
+func New(_ string, _ SkipFunc) (*Watcher, error) {
+	return nil, fmt.Errorf("watch mode on %s: %w", runtime.GOOS, errors.ErrUnsupported)
+}
+
+func (watcher *Watcher) Next() ([]string, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func (watcher *Watcher) Close() error {
+	return nil
+}
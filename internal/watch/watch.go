@@ -0,0 +1,26 @@
+package watch
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// Debounce is the time to wait for further events before changes are returned
+	Debounce = 100 * time.Millisecond
+	// All is returned if events were lost and the whole tree must be linted again
+	All = "."
+)
+
+// SkipFunc reports whether the dir should not be watched
+type SkipFunc func(path string, isDir bool) bool
+
+func sorted(changes map[string]struct{}) []string {
+	paths := make([]string, 0, len(changes))
+	for path := range changes {
+		paths = append(paths, path)
+	}
+
+	slices.Sort(paths)
+	return paths
+}
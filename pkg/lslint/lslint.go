@@ -0,0 +1,179 @@
+// Package lslint provides the ls-lint directory and filename linter as go api
+package lslint
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+
+	"github.com/loeffel-io/ls-lint/v2/internal/config"
+	"github.com/loeffel-io/ls-lint/v2/internal/debug"
+	"github.com/loeffel-io/ls-lint/v2/internal/linter"
+	"github.com/loeffel-io/ls-lint/v2/internal/rule"
+)
+
+const configFile = ".ls-lint.yml"
+
+// Rule is the interface every ls-lint rule implements
+// custom rules can be passed to Lint with Options.Rules
+type Rule = rule.Rule
+
+// Options configures a Lint run
+type Options struct {
+	// Config is the content of a .ls-lint.yml file
+	// extended config files are resolved relative to the root of the filesystem
+	Config []byte
+	// Paths limits the linting to the given files and directories
+	// all paths are linted if empty
+	Paths []string
+	// Rules registers custom rules by name
+	// custom rules take precedence over builtin rules with the same name
+	Rules map[string]Rule
+}
+
+// Result contains all lint errors of a Lint run
+type Result struct {
+	Errors []Error
+}
+
+// Error is a path which failed its configured rules
+type Error struct {
+	Path     string
+	Dir      bool
+	Ext      string
+	Severity string
+	// Message and URL are the help message and documentation url of the rule set
+	Message string
+	URL     string
+	Rules   []RuleError
+}
+
+// RuleError is a single failed rule of an Error
+type RuleError struct {
+	Name       string
+	Parameters []string
+	Message    string
+}
+
+// Rules returns the builtin rules by name including all aliases
+func Rules() map[string]Rule {
+	return maps.Clone(rule.Rules)
+}
+
+// Lint lints the filesystem with the given options
+func Lint(ctx context.Context, filesystem fs.FS, options Options) (Result, error) {
+	var err error
+
+	data := options.Config
+	if data == nil {
+		data = make([]byte, 0)
+	}
+
+	for name, r := range options.Rules {
+		if r == nil {
+			return Result{}, fmt.Errorf("rule %s is nil", name)
+		}
+	}
+
+	// extended config files are read from the filesystem
+	var lslintConfig *config.Config
+	if lslintConfig, err = config.ParseWithRules(func(name string) ([]byte, error) {
+		return fs.ReadFile(filesystem, name)
+	}, configFile, data, options.Rules); err != nil {
+		return Result{}, err
+	}
+	lslintConfig.Rules = options.Rules
+
+	var paths map[string]struct{}
+	if len(options.Paths) > 0 {
+		paths = make(map[string]struct{}, len(options.Paths))
+		for _, path := range options.Paths {
+			paths[path] = struct{}{}
+		}
+	}
+
+	lslintLinter := linter.NewLinter(
+		".",
+		lslintConfig,
+		debug.NewStatistic(),
+		make([]*rule.Error, 0),
+	)
+
+	err = lslintLinter.Run(&contextFS{ctx: ctx, fs: filesystem}, paths, false)
+
+	// the walk may fail or continue with partial results once the context is done
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return Result{}, ctxErr
+	}
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(lslintLinter.GetErrors()), nil
+}
+
+func newResult(ruleErrors []*rule.Error) Result {
+	result := Result{
+		Errors: make([]Error, 0, len(ruleErrors)),
+	}
+
+	for _, ruleErr := range ruleErrors {
+		lintErr := Error{
+			Path:     ruleErr.GetPath(),
+			Dir:      ruleErr.IsDir() || ruleErr.GetExt() == ".dir",
+			Ext:      ruleErr.GetExt(),
+			Severity: ruleErr.GetSeverity(),
+			Message:  ruleErr.GetHelp(),
+			URL:      ruleErr.GetURL(),
+			Rules:    make([]RuleError, 0, len(ruleErr.GetRules())),
+		}
+
+		if lintErr.Path == "" {
+			lintErr.Path = "."
+		}
+
+		for _, errRule := range ruleErr.GetFailedRules() {
+			lintErr.Rules = append(lintErr.Rules, RuleError{
+				Name:       errRule.GetName(),
+				Parameters: errRule.GetParameters(),
+				Message:    errRule.GetErrorMessage(),
+			})
+		}
+
+		result.Errors = append(result.Errors, lintErr)
+	}
+
+	return result
+}
+
+// contextFS aborts the filesystem walk once the context is done
+type contextFS struct {
+	ctx context.Context
+	fs  fs.FS
+}
+
+func (filesystem *contextFS) Open(name string) (fs.File, error) {
+	if err := filesystem.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return filesystem.fs.Open(name)
+}
+
+func (filesystem *contextFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := filesystem.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return fs.ReadDir(filesystem.fs, name)
+}
+
+func (filesystem *contextFS) Stat(name string) (fs.FileInfo, error) {
+	if err := filesystem.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return fs.Stat(filesystem.fs, name)
+}
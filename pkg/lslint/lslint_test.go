@@ -0,0 +1,122 @@
+package lslint
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// starts is a custom rule which requires the configured prefix
+type starts struct {
+	value string
+}
+
+func (rule *starts) Init() Rule              { return rule }
+func (rule *starts) GetName() string         { return "starts" }
+func (rule *starts) GetParameters() []string { return []string{rule.value} }
+func (rule *starts) GetExclusive() bool      { return false }
+func (rule *starts) GetErrorMessage() string { return "starts:" + rule.value }
+func (rule *starts) Copy() Rule              { return &starts{value: rule.value} }
+func (rule *starts) Validate(value string, _ string, _ bool) (bool, error) {
+	return strings.HasPrefix(value, rule.value), nil
+}
+
+func (rule *starts) SetParameters(params []string) error {
+	if len(params) == 0 || params[0] == "" {
+		return errors.New("starts value is empty")
+	}
+
+	rule.value = params[0]
+	return nil
+}
+
+func TestLint(t *testing.T) {
+	filesystem := fstest.MapFS{
+		"kebab-case.png":        &fstest.MapFile{Mode: fs.ModePerm},
+		"snake_case.png":        &fstest.MapFile{Mode: fs.ModePerm},
+		"src":                   &fstest.MapFile{Mode: fs.ModeDir},
+		"src/use-button.ts":     &fstest.MapFile{Mode: fs.ModePerm},
+		"src/button.ts":         &fstest.MapFile{Mode: fs.ModePerm},
+		"src/NotKebab":          &fstest.MapFile{Mode: fs.ModeDir},
+		"src/NotKebab/index.ts": &fstest.MapFile{Mode: fs.ModePerm},
+	}
+
+	tests := []*struct {
+		description string
+		options     Options
+		expected    Result
+		err         bool
+	}{
+		{
+			description: "builtin rules",
+			options: Options{
+				Config: []byte("ls:\n  .png: kebab-case\n  src:\n    .dir: kebab-case\n"),
+			},
+			expected: Result{
+				Errors: []Error{
+					{Path: "snake_case.png", Ext: ".png", Severity: "error", Rules: []RuleError{{Name: "kebabcase", Message: "expected snake-case.png (kebab-case)"}}},
+					{Path: "src/NotKebab", Dir: true, Ext: ".dir", Severity: "error", Rules: []RuleError{{Name: "kebabcase", Message: "expected not-kebab (kebab-case)"}}},
+				},
+			},
+		},
+		{
+			description: "custom rules",
+			options: Options{
+				Config: []byte("ls:\n  src:\n    .ts: starts:use-\n"),
+				Paths:  []string{"src/button.ts"},
+				Rules:  map[string]Rule{"starts": new(starts)},
+			},
+			expected: Result{
+				Errors: []Error{
+					{Path: "src/button.ts", Ext: ".ts", Severity: "error", Rules: []RuleError{{Name: "starts", Parameters: []string{"use-"}, Message: "starts:use-"}}},
+				},
+			},
+		},
+		{
+			description: "unknown rule",
+			options: Options{
+				Config: []byte("ls:\n  src:\n    .ts: starts:use-\n"),
+			},
+			err: true,
+		},
+	}
+
+	for i, test := range tests {
+		result, err := Lint(context.Background(), filesystem, test.options)
+
+		if (err != nil) != test.err {
+			t.Errorf("Test %d (%s) failed with unmatched error - %v", i, test.description, err)
+			continue
+		}
+
+		if test.err {
+			continue
+		}
+
+		if len(result.Errors) != len(test.expected.Errors) {
+			t.Errorf("Test %d (%s) failed with unmatched errors - %+v", i, test.description, result.Errors)
+			continue
+		}
+
+		for _, expected := range test.expected.Errors {
+			if !slices.ContainsFunc(result.Errors, func(err Error) bool { return reflect.DeepEqual(err, expected) }) {
+				t.Errorf("Test %d (%s) failed with missing error - %+v", i, test.description, expected)
+			}
+		}
+	}
+}
+
+func TestLint_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Lint(ctx, fstest.MapFS{"a.png": &fstest.MapFile{Mode: fs.ModePerm}}, Options{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unmatched error - %v", err)
+	}
+}